@@ -0,0 +1,384 @@
+package web3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DecodeResults decodes the hex-encoded return data of an eth_call according
+// to the given Solidity output types, e.g. []string{"uint112", "uint112", "uint32"}.
+func DecodeResults(outputTypes []string, data string) ([]interface{}, error) {
+	args := make(abi.Arguments, len(outputTypes))
+	for i, typeStr := range outputTypes {
+		parsed, err := ParseABIType(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output type %q: %w", typeStr, err)
+		}
+		abiType, err := parsed.gethType()
+		if err != nil {
+			return nil, fmt.Errorf("invalid output type %q: %w", typeStr, err)
+		}
+		args[i] = abi.Argument{Type: abiType}
+	}
+
+	raw, err := hex.DecodeString(StripHexPrefix(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid result data: %w", err)
+	}
+
+	values, err := args.UnpackValues(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results: %w", err)
+	}
+	return values, nil
+}
+
+// DecodeResultsInto decodes the return data like DecodeResults and assigns
+// the values, in order, to the exported fields of the struct pointed to by out.
+// Integers are converted to the field's integer type when they fit, and
+// addresses may be assigned to string fields.
+func DecodeResultsInto(outputTypes []string, data string, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("out must be a non-nil pointer to a struct, got %T", out)
+	}
+
+	values, err := DecodeResults(outputTypes, data)
+	if err != nil {
+		return err
+	}
+
+	structValue := target.Elem()
+	var fields []int
+	for i := 0; i < structValue.NumField(); i++ {
+		if structValue.Type().Field(i).IsExported() {
+			fields = append(fields, i)
+		}
+	}
+	if len(fields) < len(values) {
+		return fmt.Errorf("struct %s has %d exported fields, need %d", structValue.Type(), len(fields), len(values))
+	}
+
+	for i, value := range values {
+		if err := assignDecodedValue(structValue.Field(fields[i]), value); err != nil {
+			return fmt.Errorf("field %s: %w", structValue.Type().Field(fields[i]).Name, err)
+		}
+	}
+	return nil
+}
+
+// packABI ABI-encodes values as the given types. Common Go representations
+// are converted to what go-ethereum expects: hex strings for addresses and
+// bytes, any Go integer or *big.Int for integers, slices for arrays and
+// []interface{} for tuples.
+func packABI(types []string, values []interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(types), len(values))
+	}
+
+	args := make(abi.Arguments, len(types))
+	converted := make([]interface{}, len(values))
+	for i, typeStr := range types {
+		parsed, err := ParseABIType(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument type %q: %w", typeStr, err)
+		}
+		abiType, err := parsed.gethType()
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument type %q: %w", typeStr, err)
+		}
+		args[i] = abi.Argument{Type: abiType}
+		if converted[i], err = toABIValue(parsed, abiType.GetType(), values[i]); err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %w", i, typeStr, err)
+		}
+	}
+
+	packed, err := args.Pack(converted...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	return packed, nil
+}
+
+// toABIValue converts v to goType, the Go type go-ethereum uses for t. Values
+// it cannot convert are returned unchanged for the packer to reject.
+func toABIValue(t ABIType, goType reflect.Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, fmt.Errorf("missing value")
+	}
+	// Integers always go through the range check: go-ethereum packs an
+	// out-of-range *big.Int by silently wrapping it to 256 bits.
+	if reflect.TypeOf(v) == goType && t.Kind != ABIUint && t.Kind != ABIInt {
+		return v, nil
+	}
+	rv := reflect.ValueOf(v)
+
+	switch t.Kind {
+	case ABIAddress:
+		if s, ok := v.(string); ok {
+			if !IsAddress(EnsureHexPrefix(s)) {
+				return nil, fmt.Errorf("invalid address %q", s)
+			}
+			return common.HexToAddress(s), nil
+		}
+	case ABIUint, ABIInt:
+		n, ok := toABIInt(rv)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %T as %s", v, t)
+		}
+		if !fitsABIInt(n, t.Kind == ABIInt, t.Size) {
+			return nil, fmt.Errorf("value %s out of range for %s", n, t)
+		}
+		out := reflect.New(goType).Elem()
+		switch goType.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			out.SetUint(n.Uint64())
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			out.SetInt(n.Int64())
+		default:
+			return n, nil
+		}
+		return out.Interface(), nil
+	case ABIBytes, ABIFixedBytes:
+		var b []byte
+		switch {
+		case rv.Kind() == reflect.String:
+			decoded, err := hex.DecodeString(StripHexPrefix(rv.String()))
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex %q: %w", rv.String(), err)
+			}
+			b = decoded
+		case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8:
+			b = make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+		default:
+			return v, nil
+		}
+		if t.Kind == ABIBytes {
+			return b, nil
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		out := reflect.New(goType).Elem()
+		reflect.Copy(out, reflect.ValueOf(b))
+		return out.Interface(), nil
+	case ABIArray, ABIFixedArray:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			break
+		}
+		if t.Kind == ABIFixedArray && rv.Len() != t.Size {
+			return nil, fmt.Errorf("expected %d elements, got %d", t.Size, rv.Len())
+		}
+		out := reflect.New(goType).Elem()
+		if goType.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(goType, rv.Len(), rv.Len())
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := toABIValue(*t.Elem, goType.Elem(), rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			out.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return out.Interface(), nil
+	case ABITuple:
+		fields, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		if len(fields) != len(t.Components) {
+			return nil, fmt.Errorf("expected %d tuple fields, got %d", len(t.Components), len(fields))
+		}
+		out := reflect.New(goType).Elem()
+		for i, field := range fields {
+			elem, err := toABIValue(t.Components[i], goType.Field(i).Type, field)
+			if err != nil {
+				return nil, fmt.Errorf("field %d: %w", i, err)
+			}
+			out.Field(i).Set(reflect.ValueOf(elem))
+		}
+		return out.Interface(), nil
+	}
+	return v, nil
+}
+
+func toABIInt(rv reflect.Value) (*big.Int, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	if n, ok := rv.Interface().(*big.Int); ok && n != nil {
+		return n, true
+	}
+	return nil, false
+}
+
+// fitsABIInt reports whether n is representable as a (u)int of the given bit
+// size.
+func fitsABIInt(n *big.Int, signed bool, bits int) bool {
+	if !signed {
+		return n.Sign() >= 0 && n.BitLen() <= bits
+	}
+	if n.Sign() >= 0 {
+		return n.BitLen() < bits
+	}
+	return new(big.Int).Not(n).BitLen() < bits
+}
+
+func assignDecodedValue(field reflect.Value, value interface{}) error {
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+		return nil
+	case field.Kind() == reflect.String:
+		if addr, ok := value.(common.Address); ok {
+			field.SetString(addr.Hex())
+			return nil
+		}
+	}
+
+	if bigValue, ok := value.(*big.Int); ok {
+		switch field.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !bigValue.IsUint64() || field.OverflowUint(bigValue.Uint64()) {
+				return fmt.Errorf("value %s overflows %s", bigValue, field.Type())
+			}
+			field.SetUint(bigValue.Uint64())
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !bigValue.IsInt64() || field.OverflowInt(bigValue.Int64()) {
+				return fmt.Errorf("value %s overflows %s", bigValue, field.Type())
+			}
+			field.SetInt(bigValue.Int64())
+			return nil
+		}
+	}
+
+	if v.Type().ConvertibleTo(field.Type()) && v.Kind() != reflect.String {
+		converted := v.Convert(field.Type())
+		if !reflect.DeepEqual(converted.Convert(v.Type()).Interface(), value) {
+			return fmt.Errorf("value %v overflows %s", value, field.Type())
+		}
+		field.Set(converted)
+		return nil
+	}
+
+	return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+}
+
+// Built-in Solidity revert payloads, recognized by DecodeCustomError in
+// addition to the supplied definitions.
+var builtinErrorDefs = []string{
+	"Error(string reason)",
+	"Panic(uint256 code)",
+}
+
+// DecodeCustomError decodes revert data thrown by a Solidity custom error.
+// errorDefs are error declarations such as
+// "InsufficientBalance(uint256 available, uint256 required)" (a leading
+// "error " is allowed); the 4-byte selector of revertData picks the matching
+// one. Parameters must be elementary or array types, not tuples. Arguments
+// are keyed by parameter name, or "arg<N>" when unnamed. Error(string) and
+// Panic(uint256) are always recognized.
+func DecodeCustomError(errorDefs []string, revertData []byte) (name string, args map[string]interface{}, err error) {
+	if len(revertData) < 4 {
+		return "", nil, fmt.Errorf("revert data too short: %d bytes", len(revertData))
+	}
+
+	for _, def := range append(append([]string{}, errorDefs...), builtinErrorDefs...) {
+		errName, types, names, err := parseSignature(strings.TrimPrefix(strings.TrimSpace(def), "error "))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid error definition %q: %w", def, err)
+		}
+		signature := errName + "(" + strings.Join(types, ",") + ")"
+		if !bytes.Equal(FunctionSignature(signature).Selector(), revertData[:4]) {
+			continue
+		}
+
+		values, err := DecodeResults(types, hex.EncodeToString(revertData[4:]))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to decode %s: %w", signature, err)
+		}
+		args = make(map[string]interface{}, len(values))
+		for i, value := range values {
+			args[names[i]] = value
+		}
+		return errName, args, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown error selector 0x%x", revertData[:4])
+}
+
+// CheckSelectorCollisions computes the 4-byte selector of every function
+// signature (a leading "function " and parameter names are allowed) and
+// returns the selectors shared by more than one distinct function, keyed by
+// 0x-prefixed selector. Pass the functions of a proxy and of its
+// implementation together to detect proxy selector clashes. An empty map means
+// no collisions.
+func CheckSelectorCollisions(signatures []string) (map[string][]string, error) {
+	bySelector := make(map[string][]string)
+	for _, def := range signatures {
+		name, types, _, err := parseSignature(strings.TrimPrefix(strings.TrimSpace(def), "function "))
+		if err != nil {
+			return nil, fmt.Errorf("invalid function signature %q: %w", def, err)
+		}
+		signature := name + "(" + strings.Join(types, ",") + ")"
+		selector := fmt.Sprintf("0x%x", FunctionSignature(signature).Selector())
+		if !slices.Contains(bySelector[selector], signature) {
+			bySelector[selector] = append(bySelector[selector], signature)
+		}
+	}
+
+	collisions := make(map[string][]string)
+	for selector, functions := range bySelector {
+		if len(functions) > 1 {
+			collisions[selector] = functions
+		}
+	}
+	return collisions, nil
+}
+
+// parseSignature splits a declaration like "Transfer(address from, uint256)"
+// into its name, canonical parameter types and parameter names ("arg<N>" if
+// unnamed).
+func parseSignature(def string) (name string, types []string, names []string, err error) {
+	open := strings.Index(def, "(")
+	if open <= 0 || !strings.HasSuffix(def, ")") {
+		return "", nil, nil, fmt.Errorf("expected Name(type name, ...)")
+	}
+	name = strings.TrimSpace(def[:open])
+
+	params, err := splitTopLevel(def[open+1 : len(def)-1])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	for i, param := range params {
+		typeStr, paramName := splitParam(param)
+		if typeStr == "" {
+			return "", nil, nil, fmt.Errorf("empty parameter %d", i)
+		}
+		abiType, err := ParseABIType(typeStr)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("parameter %d: %w", i, err)
+		}
+		types = append(types, abiType.String())
+		if paramName == "" {
+			paramName = fmt.Sprintf("arg%d", i)
+		}
+		names = append(names, paramName)
+	}
+	return name, types, names, nil
+}
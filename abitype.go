@@ -0,0 +1,272 @@
+package web3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ABITypeKind is the kind of a Solidity ABI type.
+type ABITypeKind int
+
+const (
+	ABIUint       ABITypeKind = iota // uint<M>
+	ABIInt                           // int<M>
+	ABIAddress                       // address
+	ABIBool                          // bool
+	ABIString                        // string
+	ABIBytes                         // bytes
+	ABIFixedBytes                    // bytes<M>
+	ABIArray                         // T[]
+	ABIFixedArray                    // T[N]
+	ABITuple                         // (T1,T2,...)
+)
+
+// ABIType is a parsed Solidity ABI type. Size is the bit width for ABIUint
+// and ABIInt, the byte length for ABIFixedBytes and the length for
+// ABIFixedArray. Elem is the element type of arrays; Components holds the
+// members of a tuple.
+type ABIType struct {
+	Kind       ABITypeKind
+	Size       int
+	Elem       *ABIType
+	Components []ABIType
+}
+
+// ParseABIType parses a Solidity type string such as "uint256",
+// "bytes32[4]" or "(address,uint256)[]". "uint" and "int" are read as
+// their 256-bit forms, a "tuple" prefix and component names inside tuples
+// are allowed, and surrounding whitespace is ignored.
+func ParseABIType(typeStr string) (ABIType, error) {
+	s := strings.TrimSpace(typeStr)
+	if s == "" {
+		return ABIType{}, fmt.Errorf("empty ABI type")
+	}
+
+	if strings.HasSuffix(s, "]") {
+		open := strings.LastIndex(s, "[")
+		if open <= 0 {
+			return ABIType{}, fmt.Errorf("invalid array type %q", typeStr)
+		}
+		elem, err := ParseABIType(s[:open])
+		if err != nil {
+			return ABIType{}, err
+		}
+		dim := strings.TrimSpace(s[open+1 : len(s)-1])
+		if dim == "" {
+			return ABIType{Kind: ABIArray, Elem: &elem}, nil
+		}
+		length, err := strconv.Atoi(dim)
+		if err != nil || length <= 0 {
+			return ABIType{}, fmt.Errorf("invalid array length in %q", typeStr)
+		}
+		return ABIType{Kind: ABIFixedArray, Size: length, Elem: &elem}, nil
+	}
+
+	if inner, ok := strings.CutPrefix(s, "tuple"); ok && strings.HasPrefix(inner, "(") {
+		s = inner
+	}
+	if strings.HasPrefix(s, "(") {
+		if !strings.HasSuffix(s, ")") {
+			return ABIType{}, fmt.Errorf("unterminated tuple %q", typeStr)
+		}
+		members, err := splitTopLevel(s[1 : len(s)-1])
+		if err != nil {
+			return ABIType{}, fmt.Errorf("invalid tuple %q: %w", typeStr, err)
+		}
+		tuple := ABIType{Kind: ABITuple, Components: []ABIType{}}
+		for _, member := range members {
+			memberType, _ := splitParam(member)
+			component, err := ParseABIType(memberType)
+			if err != nil {
+				return ABIType{}, err
+			}
+			tuple.Components = append(tuple.Components, component)
+		}
+		return tuple, nil
+	}
+
+	switch s {
+	case "address":
+		return ABIType{Kind: ABIAddress}, nil
+	case "bool":
+		return ABIType{Kind: ABIBool}, nil
+	case "string":
+		return ABIType{Kind: ABIString}, nil
+	case "bytes":
+		return ABIType{Kind: ABIBytes}, nil
+	case "uint":
+		return ABIType{Kind: ABIUint, Size: 256}, nil
+	case "int":
+		return ABIType{Kind: ABIInt, Size: 256}, nil
+	}
+
+	for _, sized := range []struct {
+		prefix   string
+		kind     ABITypeKind
+		min, max int
+		step     int
+	}{
+		{"uint", ABIUint, 8, 256, 8},
+		{"int", ABIInt, 8, 256, 8},
+		{"bytes", ABIFixedBytes, 1, 32, 1},
+	} {
+		digits, ok := strings.CutPrefix(s, sized.prefix)
+		if !ok {
+			continue
+		}
+		size, err := strconv.Atoi(digits)
+		if err != nil || strings.HasPrefix(digits, "0") || size < sized.min || size > sized.max || size%sized.step != 0 {
+			return ABIType{}, fmt.Errorf("invalid size in ABI type %q", typeStr)
+		}
+		return ABIType{Kind: sized.kind, Size: size}, nil
+	}
+
+	return ABIType{}, fmt.Errorf("unsupported ABI type %q", typeStr)
+}
+
+// String returns the canonical type string used in signatures, e.g.
+// "uint256" or "(address,uint256)[]".
+func (t ABIType) String() string {
+	switch t.Kind {
+	case ABIUint:
+		return "uint" + strconv.Itoa(t.Size)
+	case ABIInt:
+		return "int" + strconv.Itoa(t.Size)
+	case ABIAddress:
+		return "address"
+	case ABIBool:
+		return "bool"
+	case ABIString:
+		return "string"
+	case ABIBytes:
+		return "bytes"
+	case ABIFixedBytes:
+		return "bytes" + strconv.Itoa(t.Size)
+	case ABIArray:
+		return t.Elem.String() + "[]"
+	case ABIFixedArray:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case ABITuple:
+		components := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			components[i] = component.String()
+		}
+		return "(" + strings.Join(components, ",") + ")"
+	default:
+		return fmt.Sprintf("ABITypeKind(%d)", t.Kind)
+	}
+}
+
+// IsDynamic reports whether values of the type are encoded out of place
+// (behind an offset): strings, bytes, dynamic arrays and any array or tuple
+// containing them.
+func (t ABIType) IsDynamic() bool {
+	switch t.Kind {
+	case ABIString, ABIBytes, ABIArray:
+		return true
+	case ABIFixedArray:
+		return t.Elem.IsDynamic()
+	case ABITuple:
+		for _, component := range t.Components {
+			if component.IsDynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gethType converts t to the go-ethereum ABI type used for packing and
+// unpacking. Tuple components are named arg0, arg1, ...
+func (t ABIType) gethType() (abi.Type, error) {
+	typ, components := t.marshaling()
+	return abi.NewType(typ, "", components)
+}
+
+func (t ABIType) marshaling() (string, []abi.ArgumentMarshaling) {
+	switch t.Kind {
+	case ABIArray:
+		typ, components := t.Elem.marshaling()
+		return typ + "[]", components
+	case ABIFixedArray:
+		typ, components := t.Elem.marshaling()
+		return typ + "[" + strconv.Itoa(t.Size) + "]", components
+	case ABITuple:
+		components := make([]abi.ArgumentMarshaling, len(t.Components))
+		for i, component := range t.Components {
+			typ, sub := component.marshaling()
+			components[i] = abi.ArgumentMarshaling{Name: fmt.Sprintf("arg%d", i), Type: typ, Components: sub}
+		}
+		return "tuple", components
+	default:
+		return t.String(), nil
+	}
+}
+
+// paramModifiers are keywords that may follow a parameter type but are not
+// its name.
+var paramModifiers = map[string]bool{
+	"indexed":  true,
+	"memory":   true,
+	"calldata": true,
+	"storage":  true,
+	"payable":  true,
+}
+
+// splitTopLevel splits s on commas outside parentheses and brackets. An empty
+// or all-whitespace s yields no parts.
+func splitTopLevel(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q", r)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	return append(parts, s[start:]), nil
+}
+
+// splitParam splits a parameter declaration like "(uint256 a, bool b)[] xs"
+// or "address indexed from" into its type and name; name is empty if the
+// declaration has none, e.g. "uint256 indexed".
+func splitParam(param string) (typ, name string) {
+	param = strings.TrimSpace(param)
+	depth := 0
+	for i, r := range param {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ' ', '\t', '\n':
+			if depth == 0 {
+				fields := strings.Fields(param[i:])
+				if name = fields[len(fields)-1]; paramModifiers[name] {
+					name = ""
+				}
+				return param[:i], name
+			}
+		}
+	}
+	return param, ""
+}
@@ -0,0 +1,204 @@
+package web3
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseABIType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // canonical form; empty means an error is expected
+		dynamic bool
+	}{
+		{"uint", "uint256", false},
+		{"int", "int256", false},
+		{"uint8", "uint8", false},
+		{"int136", "int136", false},
+		{"uint256", "uint256", false},
+		{" address ", "address", false},
+		{"bool", "bool", false},
+		{"string", "string", true},
+		{"bytes", "bytes", true},
+		{"bytes1", "bytes1", false},
+		{"bytes32", "bytes32", false},
+		{"uint256[]", "uint256[]", true},
+		{"address[3]", "address[3]", false},
+		{"bytes32[3][]", "bytes32[3][]", true},
+		{"string[2][3]", "string[2][3]", true},
+		{"(address,uint256)", "(address,uint256)", false},
+		{"tuple(address to, uint amount)[]", "(address,uint256)[]", true},
+		{"(uint256 a,(address,bool)[] b)[2]", "(uint256,(address,bool)[])[2]", true},
+		{"((bytes32,int8)[2],bool)", "((bytes32,int8)[2],bool)", false},
+		{"()", "()", false},
+
+		{"", "", false},
+		{"uint7", "", false},
+		{"uint264", "", false},
+		{"uint08", "", false},
+		{"int0", "", false},
+		{"bytes0", "", false},
+		{"bytes33", "", false},
+		{"uint256[0]", "", false},
+		{"uint256[-1]", "", false},
+		{"[]", "", false},
+		{"(address,uint256", "", false},
+		{"(address,(bool)", "", false},
+		{"fixed128x18", "", false},
+		{"function", "", false},
+		{"address payable", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseABIType(tt.in)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseABIType(%q) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseABIType(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseABIType(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if got.IsDynamic() != tt.dynamic {
+			t.Errorf("ParseABIType(%q).IsDynamic() = %v, want %v", tt.in, got.IsDynamic(), tt.dynamic)
+		}
+	}
+}
+
+func TestParseABITypeStructure(t *testing.T) {
+	got, err := ParseABIType("(uint64,bytes4)[2][]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Kind != ABIArray || got.Elem.Kind != ABIFixedArray || got.Elem.Size != 2 {
+		t.Fatalf("unexpected array structure: %+v", got)
+	}
+	tuple := got.Elem.Elem
+	if tuple.Kind != ABITuple || len(tuple.Components) != 2 {
+		t.Fatalf("unexpected tuple: %+v", tuple)
+	}
+	if c := tuple.Components[0]; c.Kind != ABIUint || c.Size != 64 {
+		t.Errorf("component 0 = %+v, want uint64", c)
+	}
+	if c := tuple.Components[1]; c.Kind != ABIFixedBytes || c.Size != 4 {
+		t.Errorf("component 1 = %+v, want bytes4", c)
+	}
+}
+
+func TestSplitParam(t *testing.T) {
+	tests := []struct {
+		in, typ, name string
+	}{
+		{"uint256", "uint256", ""},
+		{"uint256 amount", "uint256", "amount"},
+		{"uint256 indexed", "uint256", ""},
+		{"address indexed from", "address", "from"},
+		{"string memory", "string", ""},
+		{"bytes calldata data", "bytes", "data"},
+		{"(uint256 a, bool b)[] xs", "(uint256 a, bool b)[]", "xs"},
+		{"  bool  ", "bool", ""},
+	}
+	for _, tt := range tests {
+		typ, name := splitParam(tt.in)
+		if typ != tt.typ || name != tt.name {
+			t.Errorf("splitParam(%q) = (%q, %q), want (%q, %q)", tt.in, typ, name, tt.typ, tt.name)
+		}
+	}
+}
+
+func TestEncodeABI(t *testing.T) {
+	to := "0x00000000000000000000000000000000000000aa"
+	data, err := EncodeABI("transfer(address to, uint amount)", to, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a9059cbb" +
+		"00000000000000000000000000000000000000000000000000000000000000aa" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("EncodeABI transfer = %s, want %s", got, want)
+	}
+
+	inferred, err := EncodeABI("transfer", to, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(inferred); got != want {
+		t.Errorf("EncodeABI with inferred types = %s, want %s", got, want)
+	}
+
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	for _, tt := range []struct {
+		signature string
+		param     interface{}
+	}{
+		{"f(uint256)", maxUint256},
+		{"f(int256)", new(big.Int).Lsh(big.NewInt(-1), 255)},
+		{"f(int64)", new(big.Int).Lsh(big.NewInt(-1), 63)},
+		{"f(uint8)", big.NewInt(255)},
+	} {
+		if _, err := EncodeABI(tt.signature, tt.param); err != nil {
+			t.Errorf("EncodeABI(%q, %v) error: %v", tt.signature, tt.param, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		signature string
+		params    []interface{}
+	}{
+		{"f(uint8)", []interface{}{256}},
+		{"f(uint256)", []interface{}{-1}},
+		{"f(int8)", []interface{}{-129}},
+		{"f(bytes4)", []interface{}{"0x0102"}},
+		{"f(address)", []interface{}{"not an address"}},
+		{"f(uint256[2])", []interface{}{[]int{1}}},
+		{"f(uint256,bool)", []interface{}{1}},
+		{"f(uint7)", []interface{}{1}},
+		{"f(uint256)", []interface{}{big.NewInt(-1)}},
+		{"f(uint128)", []interface{}{new(big.Int).Lsh(big.NewInt(1), 200)}},
+		{"f(uint256)", []interface{}{new(big.Int).Lsh(big.NewInt(1), 256)}},
+		{"f(int256)", []interface{}{new(big.Int).Lsh(big.NewInt(1), 255)}},
+		{"f(int64)", []interface{}{new(big.Int).Lsh(big.NewInt(-1), 64)}},
+		{"f(uint256)", []interface{}{(*big.Int)(nil)}},
+		{"f(uint256)", []interface{}{"1"}},
+	} {
+		if _, err := EncodeABI(tt.signature, tt.params...); err == nil {
+			t.Errorf("EncodeABI(%q, %v) succeeded, want error", tt.signature, tt.params)
+		}
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	types := []string{"uint8", "int16", "bytes4", "address[]", "(uint256,bool)", "string"}
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data, err := EncodeABI("f(uint8,int16,bytes4,address[],(uint256 a,bool b),string)",
+		7, -300, "0xdeadbeef", []string{addr.Hex()}, []interface{}{big.NewInt(42), true}, "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := DecodeResults(types, hex.EncodeToString(data[4:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0].(uint8) != 7 || values[1].(int16) != -300 {
+		t.Errorf("integers = %v, %v", values[0], values[1])
+	}
+	if values[2].([4]byte) != [4]byte{0xde, 0xad, 0xbe, 0xef} {
+		t.Errorf("bytes4 = %x", values[2])
+	}
+	if addrs := values[3].([]common.Address); len(addrs) != 1 || addrs[0] != addr {
+		t.Errorf("address[] = %v", values[3])
+	}
+	if values[5].(string) != "hi" {
+		t.Errorf("string = %v", values[5])
+	}
+}
@@ -0,0 +1,77 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// BatchElem is a single request of a batch call. After BatchCall returns,
+// either Result holds the raw result or Error the error for that request.
+type BatchElem struct {
+	Method string
+	Params []interface{}
+	Result json.RawMessage
+	Error  error
+}
+
+// BatchCall sends all requests in a single JSON-RPC batch. The returned error
+// only covers transport-level failures; per-request errors are stored in each
+// element's Error field.
+func (c *Client) BatchCall(ctx context.Context, batch []BatchElem) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	reqs := make([]RPCRequest, len(batch))
+	indexByID := make(map[uint64]int, len(batch))
+	for i, elem := range batch {
+		params := elem.Params
+		if params == nil {
+			params = []interface{}{}
+		}
+		id := atomic.AddUint64(&c.idCounter, 1)
+		reqs[i] = RPCRequest{
+			ID:      id,
+			Method:  elem.Method,
+			Params:  params,
+			JSONRpc: c.jsonrpcVersion,
+		}
+		indexByID[id] = i
+	}
+
+	body, err := c.post(ctx, reqs)
+	if err != nil {
+		return err
+	}
+
+	var resps []RPCResponse
+	if err := json.Unmarshal(body, &resps); err != nil {
+		// Nodes without batch support answer with a single error object.
+		var single RPCResponse
+		if json.Unmarshal(body, &single) == nil && single.Error != nil {
+			return single.Error
+		}
+		return fmt.Errorf("failed to unmarshal batch response: %w", err)
+	}
+
+	for _, resp := range resps {
+		i, ok := indexByID[resp.ID]
+		if !ok {
+			continue
+		}
+		if resp.Error != nil {
+			batch[i].Error = resp.Error
+		} else {
+			batch[i].Result = resp.Result
+		}
+	}
+	for i := range batch {
+		if batch[i].Result == nil && batch[i].Error == nil {
+			batch[i].Error = fmt.Errorf("missing response for %s", batch[i].Method)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package web3
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultBlockTime applies to chains without a registered block time.
+const defaultBlockTime = 12 * time.Second
+
+var (
+	blockTimesMu sync.RWMutex
+	blockTimes   = map[ChainID]time.Duration{
+		ChainMainnet:        12 * time.Second,
+		ChainGoerli:         12 * time.Second,
+		ChainSepolia:        12 * time.Second,
+		ChainOptimism:       2 * time.Second,
+		ChainOptimismGoerli: 2 * time.Second,
+		ChainArbitrum:       250 * time.Millisecond,
+		ChainArbitrumGoerli: 250 * time.Millisecond,
+		ChainPolygon:        2 * time.Second,
+		ChainPolygonMumbai:  2 * time.Second,
+		ChainAvalanche:      2 * time.Second,
+		ChainAvalancheFuji:  2 * time.Second,
+		ChainBSC:            3 * time.Second,
+		ChainBSCTestnet:     3 * time.Second,
+		ChainFantom:         1 * time.Second,
+		ChainFantomTestnet:  1 * time.Second,
+	}
+)
+
+// RegisterBlockTime sets the average block time returned by BlockTime for a
+// chain, replacing any existing value. It is safe for concurrent use.
+func RegisterBlockTime(chainID ChainID, blockTime time.Duration) {
+	blockTimesMu.Lock()
+	blockTimes[chainID] = blockTime
+	blockTimesMu.Unlock()
+}
+
+// BlockTime returns the average block time of chainID, 12 seconds for
+// unknown chains.
+func BlockTime(chainID ChainID) time.Duration {
+	blockTimesMu.RLock()
+	blockTime, ok := blockTimes[chainID]
+	blockTimesMu.RUnlock()
+
+	if !ok || blockTime <= 0 {
+		return defaultBlockTime
+	}
+	return blockTime
+}
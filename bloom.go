@@ -0,0 +1,45 @@
+package web3
+
+import (
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BloomContains reports whether the hex-encoded logs bloom may contain item,
+// a 20-byte log address or a 32-byte topic. A false result is definite; a
+// true result may be a false positive. A missing or malformed bloom cannot
+// rule anything out, so it matches every item.
+func BloomContains(bloomHex string, item []byte) bool {
+	bloom, ok := parseBloom(bloomHex)
+	return !ok || bloom.Test(item)
+}
+
+// BloomMatches reports whether the block's logsBloom may contain item. See
+// BloomContains.
+func (b *Block) BloomMatches(item []byte) bool {
+	return BloomContains(b.LogsBloom, item)
+}
+
+// BloomMatchesAny reports whether the block's logsBloom may contain any of
+// items. A log watcher can skip eth_getLogs for blocks where this is false.
+func (b *Block) BloomMatchesAny(items [][]byte) bool {
+	bloom, ok := parseBloom(b.LogsBloom)
+	if !ok {
+		return true
+	}
+	for _, item := range items {
+		if bloom.Test(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBloom(bloomHex string) (types.Bloom, bool) {
+	raw, err := hex.DecodeString(StripHexPrefix(bloomHex))
+	if err != nil || len(raw) != types.BloomByteLength {
+		return types.Bloom{}, false
+	}
+	return types.BytesToBloom(raw), true
+}
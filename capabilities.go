@@ -0,0 +1,70 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Capabilities reports which optional RPC method families a provider
+// implements.
+type Capabilities struct {
+	FeeHistory    bool // eth_feeHistory
+	BlockReceipts bool // eth_getBlockReceipts
+	Trace         bool // trace_* (OpenEthereum/Erigon style)
+	Debug         bool // debug_* tracing (Geth style)
+	Subscriptions bool // eth_subscribe
+}
+
+// DetectCapabilities probes one representative method per family in a single
+// batch. A method counts as supported unless the provider answers that it
+// does not exist (see ErrMethodNotSupported); other RPC errors, such as a
+// genesis block that cannot be traced, still prove the method is there.
+// Subscriptions are only reported when eth_subscribe actually succeeds, which
+// plain HTTP endpoints never allow. Transport failures are returned as errors.
+func (c *Client) DetectCapabilities(ctx context.Context) (*Capabilities, error) {
+	genesis := BlockNumber(0).String()
+	batch := []BatchElem{
+		{Method: EthFeeHistory.String(), Params: []interface{}{ToHex(1), BlockLatest.String(), []float64{}}},
+		{Method: EthGetBlockReceipts.String(), Params: []interface{}{genesis}},
+		{Method: TraceBlock.String(), Params: []interface{}{genesis}},
+		{Method: DebugTraceBlockByNumber.String(), Params: []interface{}{genesis, map[string]interface{}{}}},
+		{Method: EthSubscribe.String(), Params: []interface{}{"newHeads"}},
+	}
+	if err := c.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		for i := range batch {
+			batch[i].Result, batch[i].Error = c.Call(ctx, batch[i].Method, batch[i].Params)
+		}
+	}
+
+	supported := make([]bool, len(batch))
+	for i, elem := range batch {
+		var rpcErr *RPCError
+		switch {
+		case elem.Error == nil:
+			supported[i] = true
+		case errors.As(elem.Error, &rpcErr):
+			supported[i] = !errors.Is(rpcErr, ErrMethodNotSupported)
+		default:
+			return nil, elem.Error
+		}
+	}
+
+	caps := &Capabilities{
+		FeeHistory:    supported[0],
+		BlockReceipts: supported[1],
+		Trace:         supported[2],
+		Debug:         supported[3],
+		Subscriptions: batch[4].Error == nil,
+	}
+	if caps.Subscriptions {
+		var id string
+		if json.Unmarshal(batch[4].Result, &id) == nil {
+			c.Call(ctx, EthUnsubscribe.String(), []interface{}{id})
+		}
+	}
+	return caps, nil
+}
@@ -4,23 +4,166 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
+	"strings"
 	"sync/atomic"
+	"time"
 )
 
+// Version is the library version reported in the default User-Agent header.
+const Version = "0.1.0"
+
+const defaultUserAgent = "go-web3/" + Version
+
 type Client struct {
-	url        string
-	httpClient *http.Client
-	idCounter  uint64
+	url            string
+	httpClient     *http.Client
+	idCounter      uint64
+	jsonrpcVersion string
+	userAgent      string
+	addressFormat  AddressFormat
+	labeler        AddressLabeler
+	maxAttempts    int
+	retryBackoff   time.Duration
+	checkChainID   bool
+	chainID        uint64 // detected chain ID, 0 until fetched
+	closed         uint32
+	cache          *immutableCache
+}
+
+// AddressFormat selects how addresses in decoded transactions, receipts, logs
+// and send results are cased. Clients use AddressFormatChecksum unless
+// configured otherwise with WithAddressFormat.
+type AddressFormat int
+
+const (
+	AddressFormatRaw       AddressFormat = iota // as returned by the node
+	AddressFormatChecksum                       // EIP-55 mixed case
+	AddressFormatLowercase                      // all lowercase
+)
+
+// ClientOption configures a Client created with NewClient.
+type ClientOption func(*Client)
+
+// WithJSONRPCVersion overrides the "jsonrpc" field sent with every request.
+// An empty version omits the field, for legacy nodes that reject it.
+func WithJSONRPCVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.jsonrpcVersion = version
+	}
+}
+
+// WithUserAgent overrides the User-Agent header, "go-web3/<version>" by
+// default, sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAddressFormat normalizes the addresses of decoded transactions and
+// receipts to the given format instead of EIP-55 checksum casing.
+// AddressFormatRaw keeps addresses exactly as the node returns them.
+func WithAddressFormat(format AddressFormat) ClientOption {
+	return func(c *Client) {
+		c.addressFormat = format
+	}
+}
+
+// AddressLabeler resolves human-readable labels (exchange or contract names)
+// for addresses.
+type AddressLabeler interface {
+	Label(address string) (string, bool)
+}
+
+// AddressBook is a static AddressLabeler keyed by lowercase address.
+type AddressBook map[string]string
+
+// NewAddressBook creates an AddressBook from labels keyed by address in any
+// case.
+func NewAddressBook(labels map[string]string) AddressBook {
+	book := make(AddressBook, len(labels))
+	for address, label := range labels {
+		book[strings.ToLower(address)] = label
+	}
+	return book
+}
+
+func (ab AddressBook) Label(address string) (string, bool) {
+	label, ok := ab[strings.ToLower(address)]
+	return label, ok
+}
+
+// WithAddressLabeler annotates decoded transactions and receipts with the
+// labels of their From and To addresses.
+func WithAddressLabeler(labeler AddressLabeler) ClientOption {
+	return func(c *Client) {
+		c.labeler = labeler
+	}
+}
+
+// WithChainIDCheck makes SendRawTransaction compare the chain ID of each
+// transaction with the node's before broadcasting, failing with
+// ErrChainIDMismatch instead of sending a transaction signed for another
+// chain. The node's chain ID is fetched once and cached.
+func WithChainIDCheck() ClientOption {
+	return func(c *Client) {
+		c.checkChainID = true
+	}
+}
+
+// WithRetry retries requests that fail with a transient error (network
+// failures, HTTP 429 and 5xx) up to maxAttempts times in total, doubling the
+// wait between attempts starting at backoff. RPC errors and context
+// cancellation are never retried. Failed requests are reported as a
+// *RetryError.
+func WithRetry(maxAttempts int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.retryBackoff = backoff
+	}
+}
+
+// WithImmutableCache memoizes up to maxEntries results that cannot change:
+// eth_getBlockByHash results, and eth_getBlockByNumber and eth_getCode
+// results for explicit block numbers at or below the finalized head (or, for
+// eth_getCode, a block hash). Code at tags such as "latest" is never cached,
+// since EIP-7702 delegations and redeployments change it. The finalized head
+// is learned from eth_getBlockByNumber("finalized") calls, so numbered
+// blocks are only cached after such a call. Batch requests bypass the cache.
+func WithImmutableCache(maxEntries int) ClientOption {
+	return func(c *Client) {
+		if maxEntries > 0 {
+			c.cache = newImmutableCache(maxEntries)
+		}
+	}
+}
+
+// RetryError is returned by a client configured with WithRetry when a request
+// fails. It records how many attempts were made and wraps the last error.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
 }
 
 type RPCRequest struct {
 	ID      uint64        `json:"id"`
 	Method  string        `json:"method"`
 	Params  []interface{} `json:"params"`
-	JSONRpc string        `json:"jsonrpc"`
+	JSONRpc string        `json:"jsonrpc,omitempty"`
 }
 
 type RPCResponse struct {
@@ -39,35 +182,308 @@ func (e *RPCError) Error() string {
 	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
 }
 
-func NewClient(url string) *Client {
-	return &Client{
-		url:        url,
-		httpClient: &http.Client{},
-		idCounter:  0,
+// ErrMethodNotSupported matches RPC errors reporting that the node or
+// provider does not implement the requested method, e.g.
+//
+//	errors.Is(err, ErrMethodNotSupported)
+var ErrMethodNotSupported = errors.New("method not supported")
+
+// JSON-RPC error code for an unknown method.
+const rpcCodeMethodNotFound = -32601
+
+// Providers word unsupported methods differently, and some use a generic
+// error code for it.
+var methodNotSupportedMessages = []string{
+	"method not found",
+	"method not supported",
+	"unsupported method",
+	"does not exist/is not available",
+}
+
+func (e *RPCError) Is(target error) bool {
+	if target != ErrMethodNotSupported {
+		return false
+	}
+	if e.Code == rpcCodeMethodNotFound {
+		return true
+	}
+	message := strings.ToLower(e.Message)
+	for _, wording := range methodNotSupportedMessages {
+		if strings.Contains(message, wording) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned when the RPC endpoint answers with a non-2xx status
+// and no JSON-RPC error object. Body holds a truncated snippet of the response.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("HTTP error %s", e.Status)
+	}
+	return fmt.Sprintf("HTTP error %s: %s", e.Status, e.Body)
+}
+
+const maxErrorBodySnippet = 512
+
+func newHTTPError(resp *http.Response, body []byte) *HTTPError {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > maxErrorBodySnippet {
+		snippet = snippet[:maxErrorBodySnippet] + "..."
+	}
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       snippet,
+	}
+}
+
+func NewClient(url string, opts ...ClientOption) *Client {
+	c := &Client{
+		url:            url,
+		httpClient:     &http.Client{},
+		idCounter:      0,
+		jsonrpcVersion: "2.0",
+		userAgent:      defaultUserAgent,
+		addressFormat:  AddressFormatChecksum,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ErrClientClosed is returned by calls made on a Client after Close.
+var ErrClientClosed = errors.New("client is closed")
+
+// Close releases the client's idle connections. Requests already in flight
+// complete normally; calls made afterwards fail with ErrClientClosed. Close
+// is safe to call more than once.
+func (c *Client) Close() error {
+	if atomic.CompareAndSwapUint32(&c.closed, 0, 1) {
+		c.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *Client) formatAddress(address string) string {
+	switch c.addressFormat {
+	case AddressFormatChecksum:
+		if checksummed, err := ToChecksumAddress(address); err == nil {
+			return checksummed
+		}
+	case AddressFormatLowercase:
+		return strings.ToLower(address)
 	}
+	return address
+}
+
+func (c *Client) labelAddress(address string) string {
+	if c.labeler == nil || address == "" {
+		return ""
+	}
+	label, _ := c.labeler.Label(address)
+	return label
 }
 
 func (c *Client) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	if c.cache != nil && atomic.LoadUint32(&c.closed) == 0 {
+		if result, ok := c.cache.get(method, params); ok {
+			return result, nil
+		}
+	}
+
+	result, _, err := c.CallWithRaw(ctx, method, params)
+	if err == nil && c.cache != nil {
+		c.cache.store(method, params, result)
+	}
+	return result, err
+}
+
+// CallInto performs a JSON-RPC call and unmarshals the result into T.
+func CallInto[T any](ctx context.Context, c *Client, method string, params []interface{}) (T, error) {
+	var out T
+	result, err := c.Call(ctx, method, params)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(result, &out); err != nil {
+		return out, fmt.Errorf("failed to unmarshal %s result: %w", method, err)
+	}
+	return out, nil
+}
+
+// CallHexBig performs a JSON-RPC call whose result is a hex quantity and
+// parses it into a big.Int.
+func (c *Client) CallHexBig(ctx context.Context, method string, params []interface{}) (*big.Int, error) {
+	hexValue, err := CallInto[string](ctx, c, method, params)
+	if err != nil {
+		return nil, err
+	}
+	value, err := FromHex(hexValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s result: %w", method, err)
+	}
+	return value, nil
+}
+
+// callHexUint64 is like CallHexBig for quantities that must fit in a uint64.
+func (c *Client) callHexUint64(ctx context.Context, method string, params []interface{}) (uint64, error) {
+	hexValue, err := CallInto[string](ctx, c, method, params)
+	if err != nil {
+		return 0, err
+	}
+	value, err := hexToUint64(hexValue)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s result: %w", method, err)
+	}
+	return value, nil
+}
+
+// CallWithRaw performs a JSON-RPC call like Call and additionally returns the
+// unparsed response body, including the id and any non-standard fields. The
+// raw body is returned alongside RPC and HTTP errors whenever it was read.
+func (c *Client) CallWithRaw(ctx context.Context, method string, params []interface{}) (json.RawMessage, []byte, error) {
 	id := atomic.AddUint64(&c.idCounter, 1)
 	
 	req := RPCRequest{
 		ID:      id,
 		Method:  method,
 		Params:  params,
-		JSONRpc: "2.0",
+		JSONRpc: c.jsonrpcVersion,
+	}
+
+	body, err := c.post(ctx, req)
+	if err != nil {
+		return nil, body, err
+	}
+
+	var rpcResp RPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return nil, body, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, body, rpcResp.Error
+	}
+
+	return rpcResp.Result, body, nil
+}
+
+// post sends a JSON-RPC payload and returns the response body, retrying
+// transient failures when configured with WithRetry. A non-2xx response is
+// reported as the RPCError it carries, or as an HTTPError.
+func (c *Client) post(ctx context.Context, payload interface{}) ([]byte, error) {
+	if atomic.LoadUint32(&c.closed) == 1 {
+		return nil, ErrClientClosed
 	}
 
-	reqBody, err := json.Marshal(req)
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	if c.maxAttempts <= 1 {
+		return c.send(ctx, reqBody)
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		body, err := c.send(ctx, reqBody)
+		if err == nil {
+			return body, nil
+		}
+		if attempt >= c.maxAttempts || !isRetryable(ctx, err) {
+			return body, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, &RetryError{Attempts: attempt, Err: ctx.Err()}
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable reports whether err is a transient transport failure. Errors
+// caused by the caller's context are never retryable.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// JSON-RPC error codes providers use for rate limiting and temporary backend
+// failures.
+const (
+	rpcCodeLimitExceeded = -32005
+	rpcCodeInternalError = -32603
+)
+
+// Messages of RPC errors that go away on their own, e.g. geth answering
+// receipt lookups while its transaction index is still being built.
+var transientRPCMessages = []string{
+	"indexing is in progress",
+	"rate limit",
+	"too many requests",
+	"timeout",
+	"try again",
+}
+
+// isPermanentError reports whether a failed request will fail again if
+// repeated unchanged: RPC errors other than rate limits, internal errors and
+// the messages above, and HTTP errors other than 429 and 5xx. Network
+// failures and context errors are not permanent.
+func isPermanentError(err error) bool {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		if rpcErr.Code == rpcCodeLimitExceeded || rpcErr.Code == rpcCodeInternalError {
+			return false
+		}
+		message := strings.ToLower(rpcErr.Message)
+		for _, wording := range transientRPCMessages {
+			if strings.Contains(message, wording) {
+				return false
+			}
+		}
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode != http.StatusTooManyRequests && httpErr.StatusCode < 500
+	}
+	return false
+}
+
+func (c *Client) send(ctx context.Context, reqBody []byte) ([]byte, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
-	
+
 	httpReq.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -80,14 +496,15 @@ func (c *Client) Call(ctx context.Context, method string, params []interface{})
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	var rpcResp RPCResponse
-	if err := json.Unmarshal(body, &rpcResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if rpcResp.Error != nil {
-		return nil, rpcResp.Error
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Some providers pair a non-2xx status with a regular JSON-RPC
+		// error object, which is more useful than the raw body.
+		var rpcResp RPCResponse
+		if err := json.Unmarshal(body, &rpcResp); err == nil && rpcResp.Error != nil {
+			return body, rpcResp.Error
+		}
+		return body, newHTTPError(resp, body)
 	}
 
-	return rpcResp.Result, nil
-}
\ No newline at end of file
+	return body, nil
+}
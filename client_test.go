@@ -0,0 +1,73 @@
+package web3
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// rpcHandler answers one JSON-RPC method in tests. Returning an *RPCError
+// sends it as the response's error; any other error fails the test.
+type rpcHandler func(params []json.RawMessage) (interface{}, error)
+
+// newTestClient returns a Client talking to a fake node that dispatches
+// single and batched requests to handlers. Unknown methods get a -32601
+// "method not found" error.
+func newTestClient(t *testing.T, handlers map[string]rpcHandler) *Client {
+	t.Helper()
+	answer := func(raw json.RawMessage) map[string]interface{} {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(raw, &req); err != nil {
+			t.Errorf("invalid request %s: %v", raw, err)
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		handler, ok := handlers[req.Method]
+		if !ok {
+			resp["error"] = &RPCError{Code: rpcCodeMethodNotFound, Message: "the method " + req.Method + " does not exist/is not available"}
+			return resp
+		}
+		result, err := handler(req.Params)
+		var rpcErr *RPCError
+		switch {
+		case errors.As(err, &rpcErr):
+			resp["error"] = rpcErr
+		case err != nil:
+			t.Errorf("%s handler: %v", req.Method, err)
+			resp["error"] = &RPCError{Code: -32000, Message: err.Error()}
+		default:
+			resp["result"] = result
+		}
+		return resp
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var out interface{}
+		var batch []json.RawMessage
+		if json.Unmarshal(body, &batch) == nil {
+			responses := make([]interface{}, len(batch))
+			for i, raw := range batch {
+				responses[i] = answer(raw)
+			}
+			out = responses
+		} else {
+			out = answer(body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL)
+}
+
+// rpcResult returns a handler that always answers with result.
+func rpcResult(result interface{}) rpcHandler {
+	return func([]json.RawMessage) (interface{}, error) { return result, nil }
+}
@@ -0,0 +1,119 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrGasPriceBelowBaseFee is returned by EstimateConfirmationTime when the
+// gas price does not cover the next block's base fee, so the transaction
+// cannot be included until the base fee falls.
+var ErrGasPriceBelowBaseFee = errors.New("gas price is below the next block's base fee")
+
+const (
+	confirmationSampleBlocks = 20
+	maxConfirmationBlocks    = 50
+)
+
+var confirmationPercentiles = []float64{10, 25, 50, 75, 90}
+
+// EstimateConfirmationTime gives a rough estimate of how long a transaction
+// paying gasPrice (the total per-gas price, or the max fee of a 1559
+// transaction) will wait for inclusion.
+//
+// The priority fee left over after the next base fee is compared with the
+// priority fee percentiles of the last 20 blocks: beating the 50th percentile
+// of a block is treated as a 50% chance of making it in. The expected number
+// of blocks is multiplied by the average block time over the same range.
+func EstimateConfirmationTime(ctx context.Context, client *Client, gasPrice *big.Int) (time.Duration, error) {
+	if gasPrice == nil || gasPrice.Sign() < 0 {
+		return 0, fmt.Errorf("invalid gas price %v", gasPrice)
+	}
+	eth := client.Eth()
+
+	history, err := eth.FeeHistory(ctx, confirmationSampleBlocks, BlockLatest, confirmationPercentiles)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	if len(history.Reward) == 0 {
+		return 0, fmt.Errorf("fee history returned no blocks")
+	}
+
+	tip := new(big.Int).Set(gasPrice)
+	if len(history.BaseFeePerGas) > 0 {
+		nextBaseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1]
+		if gasPrice.Cmp(nextBaseFee) < 0 {
+			return 0, ErrGasPriceBelowBaseFee
+		}
+		tip.Sub(tip, nextBaseFee)
+	}
+
+	var probability float64
+	for _, rewards := range history.Reward {
+		probability += inclusionChance(tip, rewards)
+	}
+	probability /= float64(len(history.Reward))
+
+	blocks := float64(maxConfirmationBlocks)
+	if probability > 1/blocks {
+		blocks = 1 / probability
+	}
+
+	blockTime, err := averageBlockTime(ctx, eth, history.OldestBlock, history.OldestBlock+uint64(len(history.Reward))-1)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(blocks * float64(blockTime)), nil
+}
+
+// inclusionChance scores tip against one block's priority fee percentiles:
+// the highest percentile it matches, or certainty if it matches them all.
+func inclusionChance(tip *big.Int, rewards []*big.Int) float64 {
+	chance := 0.0
+	for i, reward := range rewards {
+		if tip.Cmp(reward) < 0 {
+			break
+		}
+		if i == len(rewards)-1 {
+			return 1
+		}
+		chance = confirmationPercentiles[i] / 100
+	}
+	return chance
+}
+
+// averageBlockTime measures the mean time between blocks from and to.
+func averageBlockTime(ctx context.Context, eth *Eth, from, to uint64) (time.Duration, error) {
+	if to == 0 {
+		return 0, fmt.Errorf("not enough blocks to measure block time")
+	}
+	if to <= from {
+		from = to - 1
+	}
+
+	oldest, err := eth.GetBlockByNumber(ctx, BlockNumber(from), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %d: %w", from, err)
+	}
+	newest, err := eth.GetBlockByNumber(ctx, BlockNumber(to), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %d: %w", to, err)
+	}
+
+	oldestTime, err := hexToUint64(oldest.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block timestamp: %w", err)
+	}
+	newestTime, err := hexToUint64(newest.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block timestamp: %w", err)
+	}
+	if newestTime <= oldestTime {
+		return 0, fmt.Errorf("block timestamps do not increase between blocks %d and %d", from, to)
+	}
+
+	return time.Duration(newestTime-oldestTime) * time.Second / time.Duration(to-from), nil
+}
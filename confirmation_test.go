@@ -0,0 +1,22 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestEstimateConfirmationTimeInvalidGasPrice(t *testing.T) {
+	client := newTestClient(t, map[string]rpcHandler{
+		"eth_feeHistory": func([]json.RawMessage) (interface{}, error) {
+			t.Error("fee history requested for an invalid gas price")
+			return map[string]interface{}{"oldestBlock": "0x1", "baseFeePerGas": []string{"0x1", "0x1"}, "gasUsedRatio": []float64{0.5}, "reward": [][]string{{"0x1", "0x1", "0x1", "0x1", "0x1"}}}, nil
+		},
+	})
+	for _, gasPrice := range []*big.Int{nil, big.NewInt(-1)} {
+		if _, err := EstimateConfirmationTime(context.Background(), client, gasPrice); err == nil {
+			t.Errorf("EstimateConfirmationTime(%v) succeeded, want error", gasPrice)
+		}
+	}
+}
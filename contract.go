@@ -0,0 +1,252 @@
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contract binds a deployed contract address to its ABI.
+type Contract struct {
+	address string
+	abi     abi.ABI
+	client  *Client
+}
+
+// DecodedEvent is a contract event log decoded against the contract ABI.
+type DecodedEvent struct {
+	Name        string
+	Args        map[string]interface{}
+	BlockNumber uint64
+	TxHash      string
+}
+
+// DynamicIndexed is the value of an indexed string, bytes, array or tuple
+// event parameter. The topic holds only the keccak256 hash of the value, so
+// the original value cannot be recovered from the log.
+type DynamicIndexed struct {
+	Type string
+	Hash common.Hash
+}
+
+// String returns the topic hash as hex.
+func (d DynamicIndexed) String() string {
+	return d.Hash.Hex()
+}
+
+// NewContract parses abiJSON and binds it to the contract at address.
+func NewContract(address string, abiJSON string, client *Client) (*Contract, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid contract address: %s", address)
+	}
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	return &Contract{
+		address: address,
+		abi:     parsed,
+		client:  client,
+	}, nil
+}
+
+// DeployFromArtifact deploys the contract described by a compiler artifact
+// and waits for it to be mined. Hardhat/Truffle ("bytecode": "0x..."),
+// Foundry ("bytecode": {"object": ...}) and solc standard JSON
+// ("evm": {"bytecode": {"object": ...}}) layouts are accepted.
+// constructorArgs are ABI-encoded against the artifact's constructor and must
+// use the Go types of the abi package (*big.Int, common.Address, ...).
+func DeployFromArtifact(ctx context.Context, wallet *Wallet, artifactJSON []byte, constructorArgs ...interface{}) (address string, contract *Contract, receipt *TransactionReceipt, err error) {
+	abiJSON, bytecode, err := parseArtifact(artifactJSON)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	constructorData, err := parsed.Pack("", constructorArgs...)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to encode constructor arguments: %w", err)
+	}
+
+	result, err := wallet.DeployContract(ctx, bytecode, constructorData, 0, nil)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	receipt, err = wallet.WaitForTransaction(ctx, result.TransactionHash)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to wait for deployment %s: %w", result.TransactionHash, err)
+	}
+	if IsTransactionFailure(receipt) {
+		return "", nil, receipt, fmt.Errorf("deployment %s reverted", result.TransactionHash)
+	}
+	if receipt.ContractAddress == "" {
+		return "", nil, receipt, fmt.Errorf("receipt of %s has no contract address", result.TransactionHash)
+	}
+
+	contract = &Contract{
+		address: receipt.ContractAddress,
+		abi:     parsed,
+		client:  wallet.client,
+	}
+	return receipt.ContractAddress, contract, receipt, nil
+}
+
+// parseArtifact extracts the ABI and creation bytecode from a compiler
+// artifact.
+func parseArtifact(artifactJSON []byte) (json.RawMessage, []byte, error) {
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode json.RawMessage `json:"bytecode"`
+		EVM      struct {
+			Bytecode json.RawMessage `json:"bytecode"`
+		} `json:"evm"`
+	}
+	if err := json.Unmarshal(artifactJSON, &artifact); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse artifact: %w", err)
+	}
+	if len(artifact.ABI) == 0 {
+		return nil, nil, fmt.Errorf("artifact has no abi")
+	}
+
+	raw := artifact.Bytecode
+	if len(raw) == 0 {
+		raw = artifact.EVM.Bytecode
+	}
+	var code string
+	if err := json.Unmarshal(raw, &code); err != nil {
+		var object struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, nil, fmt.Errorf("artifact has no bytecode")
+		}
+		code = object.Object
+	}
+	if StripHexPrefix(code) == "" {
+		return nil, nil, fmt.Errorf("artifact bytecode is empty (abstract contract or interface?)")
+	}
+	if strings.Contains(code, "__") {
+		return nil, nil, fmt.Errorf("artifact bytecode has unlinked library references")
+	}
+	bytecode, err := hex.DecodeString(StripHexPrefix(code))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid artifact bytecode: %w", err)
+	}
+	return artifact.ABI, bytecode, nil
+}
+
+// Address returns the contract address.
+func (c *Contract) Address() string {
+	return c.address
+}
+
+// DecodeEventLog decodes log using the ABI event whose signature hash matches
+// the log's first topic.
+func (c *Contract) DecodeEventLog(log Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+	event, err := c.abi.EventByID(common.HexToHash(log.Topics[0]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown event topic %s: %w", log.Topics[0], err)
+	}
+
+	args := make(map[string]interface{})
+	if log.Data != "" && log.Data != "0x" {
+		data, err := hex.DecodeString(StripHexPrefix(log.Data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log data: %w", err)
+		}
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(args, data); err != nil {
+			return nil, fmt.Errorf("failed to decode %s data: %w", event.Name, err)
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf("%s has %d indexed parameters but log has %d topics", event.Name, len(indexed), len(log.Topics)-1)
+	}
+
+	// Indexed dynamic values are stored as their keccak hash, so they are
+	// exposed as DynamicIndexed and only static ones are decoded.
+	var static abi.Arguments
+	var topics []common.Hash
+	for i, input := range indexed {
+		topic := common.HexToHash(log.Topics[i+1])
+		if isDynamicTopicType(input.Type) {
+			args[input.Name] = DynamicIndexed{Type: input.Type.String(), Hash: topic}
+			continue
+		}
+		static = append(static, input)
+		topics = append(topics, topic)
+	}
+	if err := abi.ParseTopicsIntoMap(args, static, topics); err != nil {
+		return nil, fmt.Errorf("failed to decode %s topics: %w", event.Name, err)
+	}
+
+	decoded := &DecodedEvent{
+		Name:   event.Name,
+		Args:   args,
+		TxHash: log.TransactionHash,
+	}
+	if log.BlockNumber != "" {
+		blockNumber, err := hexToUint64(log.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log block number: %w", err)
+		}
+		decoded.BlockNumber = blockNumber
+	}
+	return decoded, nil
+}
+
+// GetEvents fetches every eventName log emitted by the contract between the
+// from and to blocks, paginating the query, and decodes each one.
+func (c *Contract) GetEvents(ctx context.Context, eventName string, from, to BlockParameter) ([]DecodedEvent, error) {
+	event, ok := c.abi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", eventName)
+	}
+
+	logs, err := c.client.Eth().GetLogsPaged(ctx, LogFilter{
+		FromBlock: from,
+		ToBlock:   to,
+		Addresses: []string{c.address},
+		Topics:    [][]string{{event.ID.Hex()}},
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]DecodedEvent, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		decoded, err := c.DecodeEventLog(log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *decoded)
+	}
+	return events, nil
+}
+
+func isDynamicTopicType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package web3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NameHash computes the ENS namehash of name, e.g. the node used as key by
+// the ENS registry and resolvers. The empty name hashes to 32 zero bytes.
+// Names must already be normalized (lowercase, UTS-46); labels must not be
+// empty.
+func NameHash(name string) ([32]byte, error) {
+	var node [32]byte
+	if name == "" {
+		return node, nil
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		if labels[i] == "" {
+			return [32]byte{}, fmt.Errorf("invalid ENS name %q: empty label", name)
+		}
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		copy(node[:], crypto.Keccak256(node[:], labelHash))
+	}
+	return node, nil
+}
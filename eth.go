@@ -2,9 +2,16 @@ package web3
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type Eth struct {
@@ -20,51 +27,15 @@ func (e *Eth) GetBalance(ctx context.Context, address string, blockNumber BlockP
 		blockNumber = BlockLatest
 	}
 	
-	result, err := e.client.Call(ctx, EthGetBalance.String(), []interface{}{address, blockNumber.String()})
-	if err != nil {
-		return nil, err
-	}
-
-	var hexValue string
-	if err := json.Unmarshal(result, &hexValue); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal balance: %w", err)
-	}
-
-	balance := new(big.Int)
-	balance.SetString(hexValue[2:], 16)
-	return balance, nil
+	return e.client.CallHexBig(ctx, EthGetBalance.String(), []interface{}{address, blockNumber.String()})
 }
 
 func (e *Eth) GetBlockNumber(ctx context.Context) (uint64, error) {
-	result, err := e.client.Call(ctx, EthGetBlockNumber.String(), []interface{}{})
-	if err != nil {
-		return 0, err
-	}
-
-	var hexValue string
-	if err := json.Unmarshal(result, &hexValue); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal block number: %w", err)
-	}
-
-	blockNumber := new(big.Int)
-	blockNumber.SetString(hexValue[2:], 16)
-	return blockNumber.Uint64(), nil
+	return e.client.callHexUint64(ctx, EthGetBlockNumber.String(), []interface{}{})
 }
 
 func (e *Eth) GetGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := e.client.Call(ctx, EthGetGasPrice.String(), []interface{}{})
-	if err != nil {
-		return nil, err
-	}
-
-	var hexValue string
-	if err := json.Unmarshal(result, &hexValue); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal gas price: %w", err)
-	}
-
-	gasPrice := new(big.Int)
-	gasPrice.SetString(hexValue[2:], 16)
-	return gasPrice, nil
+	return e.client.CallHexBig(ctx, EthGetGasPrice.String(), []interface{}{})
 }
 
 func (e *Eth) GetTransactionCount(ctx context.Context, address string, blockNumber BlockParameter) (uint64, error) {
@@ -72,19 +43,7 @@ func (e *Eth) GetTransactionCount(ctx context.Context, address string, blockNumb
 		blockNumber = BlockLatest
 	}
 	
-	result, err := e.client.Call(ctx, EthGetTransactionCount.String(), []interface{}{address, blockNumber.String()})
-	if err != nil {
-		return 0, err
-	}
-
-	var hexValue string
-	if err := json.Unmarshal(result, &hexValue); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal transaction count: %w", err)
-	}
-
-	nonce := new(big.Int)
-	nonce.SetString(hexValue[2:], 16)
-	return nonce.Uint64(), nil
+	return e.client.callHexUint64(ctx, EthGetTransactionCount.String(), []interface{}{address, blockNumber.String()})
 }
 
 type Block struct {
@@ -107,6 +66,42 @@ type Block struct {
 	Timestamp        string        `json:"timestamp"`
 	Transactions     []interface{} `json:"transactions"`
 	Uncles           []string      `json:"uncles"`
+	BaseFeePerGas    string        `json:"baseFeePerGas,omitempty"`
+	WithdrawalsRoot  string        `json:"withdrawalsRoot,omitempty"`
+	Withdrawals      []Withdrawal  `json:"withdrawals,omitempty"`
+}
+
+// Withdrawal is a validator withdrawal included in a post-Shanghai block.
+// Quantities are hex-encoded as returned by the node; Amount is in Gwei.
+type Withdrawal struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
+
+// AmountWei returns the withdrawn amount converted from Gwei to wei.
+func (w Withdrawal) AmountWei() (*big.Int, error) {
+	gwei, err := FromHex(w.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid withdrawal amount: %w", err)
+	}
+	return gwei.Mul(gwei, big.NewInt(1e9)), nil
+}
+
+// TransactionHashes returns the transaction hashes of a block fetched with
+// fullTransactions set to false. It returns an error if the block holds full
+// transaction objects instead.
+func (b *Block) TransactionHashes() ([]string, error) {
+	hashes := make([]string, 0, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hash, ok := tx.(string)
+		if !ok {
+			return nil, fmt.Errorf("transaction %d is %T, not a hash; block was fetched with full transactions", i, tx)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
 }
 
 func (e *Eth) GetBlockByNumber(ctx context.Context, blockNumber BlockParameter, fullTransactions bool) (*Block, error) {
@@ -141,34 +136,214 @@ func (e *Eth) GetBlockByHash(ctx context.Context, blockHash string, fullTransact
 	return &block, nil
 }
 
+// GetUncleCountByBlockNumber returns the number of uncles in a block.
+func (e *Eth) GetUncleCountByBlockNumber(ctx context.Context, blockNumber BlockParameter) (uint64, error) {
+	if blockNumber == "" {
+		blockNumber = BlockLatest
+	}
+	return e.client.callHexUint64(ctx, EthGetUncleCountByBlockNumber.String(), []interface{}{blockNumber.String()})
+}
+
+// ChainTip is a snapshot of the head of the chain, as returned by
+// GetChainTip. BaseFee is nil on chains without EIP-1559.
+type ChainTip struct {
+	BlockNumber uint64
+	Timestamp   uint64
+	BaseFee     *big.Int
+	GasPrice    *big.Int
+}
+
+// GetChainTip fetches the block number, gas price and latest block header in
+// a single batch request, falling back to separate calls if the node rejects
+// batches.
+func (e *Eth) GetChainTip(ctx context.Context) (*ChainTip, error) {
+	batch := []BatchElem{
+		{Method: EthGetBlockNumber.String()},
+		{Method: EthGetGasPrice.String()},
+		{Method: EthGetBlockByNumber.String(), Params: []interface{}{BlockLatest.String(), false}},
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return e.getChainTipSequential(ctx)
+	}
+
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("%s failed: %w", elem.Method, elem.Error)
+		}
+	}
+
+	var blockNumberHex, gasPriceHex string
+	if err := json.Unmarshal(batch[0].Result, &blockNumberHex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block number: %w", err)
+	}
+	if err := json.Unmarshal(batch[1].Result, &gasPriceHex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gas price: %w", err)
+	}
+	var block Block
+	if err := json.Unmarshal(batch[2].Result, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+
+	blockNumber, err := hexToUint64(blockNumberHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %w", err)
+	}
+	gasPrice, err := FromHex(gasPriceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price: %w", err)
+	}
+	return newChainTip(blockNumber, gasPrice, &block)
+}
+
+func (e *Eth) getChainTipSequential(ctx context.Context) (*ChainTip, error) {
+	blockNumber, err := e.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := e.GetGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	block, err := e.GetBlockByNumber(ctx, BlockLatest, false)
+	if err != nil {
+		return nil, err
+	}
+	return newChainTip(blockNumber, gasPrice, block)
+}
+
+func newChainTip(blockNumber uint64, gasPrice *big.Int, block *Block) (*ChainTip, error) {
+	tip := &ChainTip{
+		BlockNumber: blockNumber,
+		GasPrice:    gasPrice,
+	}
+
+	timestamp, err := hexToUint64(block.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block timestamp: %w", err)
+	}
+	tip.Timestamp = timestamp
+
+	if block.BaseFeePerGas != "" {
+		if tip.BaseFee, err = FromHex(block.BaseFeePerGas); err != nil {
+			return nil, fmt.Errorf("invalid base fee: %w", err)
+		}
+	}
+	return tip, nil
+}
+
 type Transaction struct {
-	Hash             string `json:"hash"`
-	Nonce            string `json:"nonce"`
-	BlockHash        string `json:"blockHash"`
-	BlockNumber      string `json:"blockNumber"`
-	TransactionIndex string `json:"transactionIndex"`
-	From             string `json:"from"`
-	To               string `json:"to"`
-	Value            string `json:"value"`
-	Gas              string `json:"gas"`
-	GasPrice         string `json:"gasPrice"`
-	Input            string `json:"input"`
+	Hash             string        `json:"hash"`
+	Nonce            string        `json:"nonce"`
+	BlockHash        string        `json:"blockHash"`
+	BlockNumber      string        `json:"blockNumber"`
+	TransactionIndex string        `json:"transactionIndex"`
+	From             string        `json:"from"`
+	To               string        `json:"to"`
+	Value            string        `json:"value"`
+	Gas              string        `json:"gas"`
+	GasPrice         string        `json:"gasPrice"`
+	Input            string        `json:"input"`
+	Type             TxType        `json:"type"`
+	AccessList       []AccessTuple `json:"accessList"`
+
+	// EIP-1559 fee caps, empty for legacy and access list transactions.
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+
+	// Labels from the client's AddressLabeler, if any.
+	FromLabel string `json:"fromLabel,omitempty"`
+	ToLabel   string `json:"toLabel,omitempty"`
+}
+
+// UnmarshalJSON reads calldata from "input", falling back to "data" for
+// nodes that still use the older field name.
+func (tx *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	var dec struct {
+		transaction
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*tx = Transaction(dec.transaction)
+	if tx.Input == "" {
+		tx.Input = dec.Data
+	}
+	return nil
 }
 
+// GetTransactionByHash returns the transaction with the given hash, or nil if
+// the node does not know it.
 func (e *Eth) GetTransactionByHash(ctx context.Context, txHash string) (*Transaction, error) {
 	result, err := e.client.Call(ctx, EthGetTransactionByHash.String(), []interface{}{txHash})
 	if err != nil {
 		return nil, err
 	}
 
+	return e.decodeTransaction(result)
+}
+
+// GetTransactionsByHash resolves many transaction hashes in a single batch
+// request. Hashes unknown to the node map to nil.
+func (e *Eth) GetTransactionsByHash(ctx context.Context, hashes []string) (map[string]*Transaction, error) {
+	batch := make([]BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = BatchElem{
+			Method: EthGetTransactionByHash.String(),
+			Params: []interface{}{hash},
+		}
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	txs := make(map[string]*Transaction, len(hashes))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", hashes[i], elem.Error)
+		}
+		tx, err := e.decodeTransaction(elem.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", hashes[i], err)
+		}
+		txs[hashes[i]] = tx
+	}
+	return txs, nil
+}
+
+func (e *Eth) decodeTransaction(result json.RawMessage) (*Transaction, error) {
+	if isNullResult(result) {
+		return nil, nil
+	}
+
 	var tx Transaction
 	if err := json.Unmarshal(result, &tx); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
 	}
+	e.normalizeTransaction(&tx)
 
 	return &tx, nil
 }
 
+func (e *Eth) normalizeTransaction(tx *Transaction) {
+	tx.From = e.client.formatAddress(tx.From)
+	tx.To = e.client.formatAddress(tx.To)
+	tx.FromLabel = e.client.labelAddress(tx.From)
+	tx.ToLabel = e.client.labelAddress(tx.To)
+}
+
+func (e *Eth) normalizeReceipt(receipt *TransactionReceipt) {
+	receipt.From = e.client.formatAddress(receipt.From)
+	receipt.To = e.client.formatAddress(receipt.To)
+	receipt.ContractAddress = e.client.formatAddress(receipt.ContractAddress)
+	receipt.FromLabel = e.client.labelAddress(receipt.From)
+	receipt.ToLabel = e.client.labelAddress(receipt.To)
+}
+
 type TransactionReceipt struct {
 	TransactionHash   string `json:"transactionHash"`
 	TransactionIndex  string `json:"transactionIndex"`
@@ -179,24 +354,127 @@ type TransactionReceipt struct {
 	CumulativeGasUsed string `json:"cumulativeGasUsed"`
 	GasUsed           string `json:"gasUsed"`
 	ContractAddress   string `json:"contractAddress"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
 	Status            string `json:"status"`
+	Root              string `json:"root,omitempty"` // post-state root of pre-Byzantium receipts
+	Type              TxType `json:"type"`
+	LogsBloom         string `json:"logsBloom"`
+	Logs              []Log  `json:"logs"`
+
+	// Labels from the client's AddressLabeler, if any.
+	FromLabel string `json:"fromLabel,omitempty"`
+	ToLabel   string `json:"toLabel,omitempty"`
+}
+
+// TotalFee returns the fee paid for the transaction, gasUsed multiplied by
+// effectiveGasPrice.
+func (r *TransactionReceipt) TotalFee() (*big.Int, error) {
+	if r.EffectiveGasPrice == "" {
+		return nil, fmt.Errorf("receipt has no effective gas price")
+	}
+	gasUsed, err := FromHex(r.GasUsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas used: %w", err)
+	}
+	gasPrice, err := FromHex(r.EffectiveGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective gas price: %w", err)
+	}
+	return new(big.Int).Mul(gasUsed, gasPrice), nil
 }
 
+// GetTransactionReceipt returns the receipt of a mined transaction, or nil
+// if the transaction is unknown or not yet mined.
 func (e *Eth) GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceipt, error) {
 	result, err := e.client.Call(ctx, EthGetTransactionReceipt.String(), []interface{}{txHash})
 	if err != nil {
 		return nil, err
 	}
 
+	return e.decodeReceipt(result)
+}
+
+func (e *Eth) decodeReceipt(result json.RawMessage) (*TransactionReceipt, error) {
+	if isNullResult(result) {
+		return nil, nil
+	}
+
 	var receipt TransactionReceipt
 	if err := json.Unmarshal(result, &receipt); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal transaction receipt: %w", err)
 	}
+	e.normalizeReceipt(&receipt)
 
 	return &receipt, nil
 }
 
+// GetTransactionWithReceipt fetches a transaction and its receipt in a single
+// batch request, falling back to two calls if the node rejects batches. The
+// receipt is nil while the transaction is pending; both are nil if the
+// transaction is unknown.
+func (e *Eth) GetTransactionWithReceipt(ctx context.Context, txHash string) (*Transaction, *TransactionReceipt, error) {
+	batch := []BatchElem{
+		{Method: EthGetTransactionByHash.String(), Params: []interface{}{txHash}},
+		{Method: EthGetTransactionReceipt.String(), Params: []interface{}{txHash}},
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, err
+		}
+		tx, err := e.GetTransactionByHash(ctx, txHash)
+		if err != nil || tx == nil {
+			return nil, nil, err
+		}
+		receipt, err := e.GetTransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tx, receipt, nil
+	}
+
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, nil, elem.Error
+		}
+	}
+
+	tx, err := e.decodeTransaction(batch[0].Result)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := e.decodeReceipt(batch[1].Result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, receipt, nil
+}
+
+// ErrChainIDMismatch is returned for transactions signed for a different
+// chain than expected: by SendRawTransaction on clients created with
+// WithChainIDCheck, and by DecodeAndVerify.
+var ErrChainIDMismatch = errors.New("transaction chain ID mismatch")
+
+// GetChainID returns the chain ID reported by the node via eth_chainId.
+func (e *Eth) GetChainID(ctx context.Context) (ChainID, error) {
+	if cached := atomic.LoadUint64(&e.client.chainID); cached != 0 {
+		return ChainID(cached), nil
+	}
+
+	chainID, err := e.client.callHexUint64(ctx, EthChainId.String(), []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+	atomic.StoreUint64(&e.client.chainID, chainID)
+	return ChainID(chainID), nil
+}
+
 func (e *Eth) SendRawTransaction(ctx context.Context, signedTx string) (string, error) {
+	if e.client.checkChainID {
+		if err := e.checkChainID(ctx, signedTx); err != nil {
+			return "", err
+		}
+	}
+
 	result, err := e.client.Call(ctx, EthSendRawTransaction.String(), []interface{}{signedTx})
 	if err != nil {
 		return "", err
@@ -210,28 +488,322 @@ func (e *Eth) SendRawTransaction(ctx context.Context, signedTx string) (string,
 	return txHash, nil
 }
 
-func (e *Eth) EstimateGas(ctx context.Context, tx map[string]interface{}) (uint64, error) {
-	result, err := e.client.Call(ctx, EthEstimateGas.String(), []interface{}{tx})
+func (e *Eth) checkChainID(ctx context.Context, signedTx string) error {
+	rawTx, err := hex.DecodeString(StripHexPrefix(signedTx))
 	if err != nil {
-		return 0, err
+		return fmt.Errorf("invalid hex string: %w", err)
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		// Types this package encodes itself, such as set-code
+		// transactions, are not checked.
+		if errors.Is(err, types.ErrTxTypeNotSupported) {
+			return nil
+		}
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	// Pre-EIP-155 legacy transactions are valid on every chain.
+	if tx.ChainId().Sign() == 0 {
+		return nil
+	}
+
+	nodeChainID, err := e.GetChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if tx.ChainId().Cmp(nodeChainID.BigInt()) != 0 {
+		return fmt.Errorf("%w: transaction is for chain %s, node is on chain %d", ErrChainIDMismatch, tx.ChainId(), nodeChainID)
+	}
+	return nil
+}
+
+// AccessTuple is an EIP-2930 access list entry.
+type AccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// CallObject describes the transaction object accepted by eth_call and
+// eth_estimateGas. Unset fields are omitted from the encoded request.
+type CallObject struct {
+	From                 string
+	To                   string
+	Value                *big.Int
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Data                 []byte
+	AccessList           []AccessTuple
+}
+
+func NewCallObject(to string) *CallObject {
+	return &CallObject{To: to}
+}
+
+func (co *CallObject) SetFrom(address string) *CallObject {
+	co.From = address
+	return co
+}
+
+func (co *CallObject) SetTo(address string) *CallObject {
+	co.To = address
+	return co
+}
+
+func (co *CallObject) SetValue(value *big.Int) *CallObject {
+	co.Value = value
+	return co
+}
+
+func (co *CallObject) SetGas(gas uint64) *CallObject {
+	co.Gas = gas
+	return co
+}
+
+func (co *CallObject) SetGasPrice(gasPrice *big.Int) *CallObject {
+	co.GasPrice = gasPrice
+	return co
+}
+
+func (co *CallObject) SetData(data []byte) *CallObject {
+	co.Data = data
+	return co
+}
+
+// SetFees sets EIP-1559 fee fields so the call is evaluated as a type-2
+// transaction.
+func (co *CallObject) SetFees(maxFeePerGas, maxPriorityFeePerGas *big.Int) *CallObject {
+	co.MaxFeePerGas = maxFeePerGas
+	co.MaxPriorityFeePerGas = maxPriorityFeePerGas
+	return co
+}
+
+func (co *CallObject) SetAccessList(accessList []AccessTuple) *CallObject {
+	co.AccessList = accessList
+	return co
+}
+
+// ToMap encodes the call object into the JSON-RPC shape, hex-encoding
+// numeric and byte fields and leaving out anything that is unset.
+func (co *CallObject) ToMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if co == nil {
+		return m
+	}
+	if co.From != "" {
+		m["from"] = co.From
+	}
+	if co.To != "" {
+		m["to"] = co.To
+	}
+	if co.Value != nil {
+		m["value"] = ToHex(co.Value)
+	}
+	if co.Gas != 0 {
+		m["gas"] = ToHex(co.Gas)
+	}
+	if co.GasPrice != nil {
+		m["gasPrice"] = ToHex(co.GasPrice)
+	}
+	if co.MaxFeePerGas != nil {
+		m["maxFeePerGas"] = ToHex(co.MaxFeePerGas)
+	}
+	if co.MaxPriorityFeePerGas != nil {
+		m["maxPriorityFeePerGas"] = ToHex(co.MaxPriorityFeePerGas)
+	}
+	if len(co.Data) > 0 {
+		m["data"] = ToHex(co.Data)
+	}
+	if co.AccessList != nil {
+		m["accessList"] = co.AccessList
+	}
+	return m
+}
+
+func (e *Eth) EstimateGas(ctx context.Context, callObj *CallObject) (uint64, error) {
+	return e.client.callHexUint64(ctx, EthEstimateGas.String(), []interface{}{callObj.ToMap()})
+}
+
+// AccessListResult is the result of eth_createAccessList.
+type AccessListResult struct {
+	AccessList []AccessTuple `json:"accessList"`
+	GasUsed    string        `json:"gasUsed"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// CreateAccessList asks the node for the access list the call would touch,
+// along with the gas it uses when that list is attached.
+func (e *Eth) CreateAccessList(ctx context.Context, callObj *CallObject, blockNumber BlockParameter) (*AccessListResult, error) {
+	if blockNumber == "" {
+		blockNumber = BlockLatest
 	}
 
-	var hexValue string
-	if err := json.Unmarshal(result, &hexValue); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal gas estimate: %w", err)
+	result, err := e.client.Call(ctx, EthCreateAccessList.String(), []interface{}{callObj.ToMap(), blockNumber.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var accessList AccessListResult
+	if err := json.Unmarshal(result, &accessList); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal access list: %w", err)
+	}
+	if accessList.Error != "" {
+		return nil, fmt.Errorf("failed to create access list: %s", accessList.Error)
+	}
+
+	return &accessList, nil
+}
+
+// FeeHistory is the decoded result of eth_feeHistory. BaseFeePerGas holds one
+// more entry than GasUsedRatio: the base fee of the block after the range.
+// Reward holds, per block, the priority fees at the requested percentiles.
+type FeeHistory struct {
+	OldestBlock   uint64
+	BaseFeePerGas []*big.Int
+	GasUsedRatio  []float64
+	Reward        [][]*big.Int
+}
+
+// FeeHistory returns base fees, gas usage and priority fee percentiles for the
+// blockCount blocks ending at newestBlock.
+func (e *Eth) FeeHistory(ctx context.Context, blockCount uint64, newestBlock BlockParameter, rewardPercentiles []float64) (*FeeHistory, error) {
+	if newestBlock == "" {
+		newestBlock = BlockLatest
+	}
+	if rewardPercentiles == nil {
+		rewardPercentiles = []float64{}
+	}
+
+	raw, err := CallInto[struct {
+		OldestBlock   string     `json:"oldestBlock"`
+		BaseFeePerGas []string   `json:"baseFeePerGas"`
+		GasUsedRatio  []float64  `json:"gasUsedRatio"`
+		Reward        [][]string `json:"reward"`
+	}](ctx, e.client, EthFeeHistory.String(), []interface{}{ToHex(blockCount), newestBlock.String(), rewardPercentiles})
+	if err != nil {
+		return nil, err
+	}
+
+	history := &FeeHistory{GasUsedRatio: raw.GasUsedRatio}
+	if history.OldestBlock, err = hexToUint64(raw.OldestBlock); err != nil {
+		return nil, fmt.Errorf("invalid oldestBlock: %w", err)
+	}
+	for _, fee := range raw.BaseFeePerGas {
+		baseFee, err := FromHex(fee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid baseFeePerGas: %w", err)
+		}
+		history.BaseFeePerGas = append(history.BaseFeePerGas, baseFee)
+	}
+	for _, blockRewards := range raw.Reward {
+		rewards := make([]*big.Int, len(blockRewards))
+		for i, reward := range blockRewards {
+			if rewards[i], err = FromHex(reward); err != nil {
+				return nil, fmt.Errorf("invalid reward: %w", err)
+			}
+		}
+		history.Reward = append(history.Reward, rewards)
+	}
+	return history, nil
+}
+
+// GetBaseFee returns the base fee of a block. It asks eth_feeHistory for a
+// single block, which carries no transactions, and reads the block header
+// instead if the node does not support fee history. Blocks without a base
+// fee (pre-London) are not an error: eth_feeHistory reports them as zero and
+// the header fallback returns nil, so callers should treat both as "no base
+// fee".
+func (e *Eth) GetBaseFee(ctx context.Context, block BlockParameter) (*big.Int, error) {
+	if block == "" {
+		block = BlockLatest
+	}
+
+	history, err := e.FeeHistory(ctx, 1, block, nil)
+	if err == nil {
+		if len(history.BaseFeePerGas) == 0 {
+			return nil, fmt.Errorf("no base fee returned for block %s", block)
+		}
+		return history.BaseFeePerGas[0], nil
+	}
+	if !errors.Is(err, ErrMethodNotSupported) {
+		return nil, err
+	}
+
+	header, err := CallInto[struct {
+		BaseFeePerGas string `json:"baseFeePerGas"`
+	}](ctx, e.client, EthGetBlockByNumber.String(), []interface{}{block.String(), false})
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFeePerGas == "" {
+		return nil, nil
+	}
+	return FromHex(header.BaseFeePerGas)
+}
+
+// EIP1559Fees is a fee pair for a dynamic fee transaction.
+type EIP1559Fees struct {
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// Recommend turns the history into slow, standard and fast fees. The tips are
+// the median, across blocks, of the first, middle and last reward percentile
+// columns, so request the history with e.g. []float64{10, 50, 90}. Each
+// MaxFeePerGas is twice the next block's base fee plus the tip, which stays
+// valid through several consecutive full blocks. Without reward percentiles
+// all tips are zero.
+func (fh *FeeHistory) Recommend() (slow, standard, fast EIP1559Fees) {
+	baseFee := new(big.Int)
+	if n := len(fh.BaseFeePerGas); n > 0 && fh.BaseFeePerGas[n-1] != nil {
+		baseFee.Set(fh.BaseFeePerGas[n-1])
+	}
+
+	columns := 0
+	for _, rewards := range fh.Reward {
+		columns = max(columns, len(rewards))
+	}
+	tier := func(column int) EIP1559Fees {
+		tip := new(big.Int)
+		if columns > 0 {
+			tip = fh.medianReward(column)
+		}
+		maxFee := new(big.Int).Mul(baseFee, big.NewInt(2))
+		return EIP1559Fees{
+			MaxFeePerGas:         maxFee.Add(maxFee, tip),
+			MaxPriorityFeePerGas: tip,
+		}
 	}
+	return tier(0), tier((columns - 1) / 2), tier(columns - 1)
+}
 
-	gasEstimate := new(big.Int)
-	gasEstimate.SetString(hexValue[2:], 16)
-	return gasEstimate.Uint64(), nil
+// medianReward returns the median of one reward column, skipping empty blocks
+// whose rewards are all zero.
+func (fh *FeeHistory) medianReward(column int) *big.Int {
+	var values []*big.Int
+	for i, rewards := range fh.Reward {
+		if column >= len(rewards) || rewards[column] == nil {
+			continue
+		}
+		if i < len(fh.GasUsedRatio) && fh.GasUsedRatio[i] == 0 {
+			continue
+		}
+		values = append(values, rewards[column])
+	}
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+	return new(big.Int).Set(values[len(values)/2])
 }
 
-func (e *Eth) Call(ctx context.Context, callObj map[string]interface{}, blockNumber BlockParameter) (string, error) {
+func (e *Eth) Call(ctx context.Context, callObj *CallObject, blockNumber BlockParameter) (string, error) {
 	if blockNumber == "" {
 		blockNumber = BlockLatest
 	}
 	
-	result, err := e.client.Call(ctx, EthCall.String(), []interface{}{callObj, blockNumber.String()})
+	result, err := e.client.Call(ctx, EthCall.String(), []interface{}{callObj.ToMap(), blockNumber.String()})
 	if err != nil {
 		return "", err
 	}
@@ -244,6 +816,201 @@ func (e *Eth) Call(ctx context.Context, callObj map[string]interface{}, blockNum
 	return data, nil
 }
 
+// GetCode returns the deployed bytecode at address, empty for accounts
+// without code.
+func (e *Eth) GetCode(ctx context.Context, address string, blockNumber BlockParameter) ([]byte, error) {
+	if blockNumber == "" {
+		blockNumber = BlockLatest
+	}
+
+	result, err := e.client.Call(ctx, EthGetCode.String(), []interface{}{address, blockNumber.String()})
+	if err != nil {
+		return nil, err
+	}
+	return decodeHexBytes(result)
+}
+
+// AddressInfo summarizes an account, as returned by InspectAddress.
+type AddressInfo struct {
+	Address    string
+	Balance    *big.Int
+	Nonce      uint64
+	IsContract bool
+	CodeSize   int
+}
+
+// InspectAddress fetches the balance, nonce and code of address at the latest
+// block in a single batch request, falling back to three calls if the node
+// rejects batches.
+func (e *Eth) InspectAddress(ctx context.Context, address string) (*AddressInfo, error) {
+	batch := []BatchElem{
+		{Method: EthGetBalance.String(), Params: []interface{}{address, BlockLatest.String()}},
+		{Method: EthGetTransactionCount.String(), Params: []interface{}{address, BlockLatest.String()}},
+		{Method: EthGetCode.String(), Params: []interface{}{address, BlockLatest.String()}},
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return e.inspectAddressSequential(ctx, address)
+	}
+
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("%s failed: %w", elem.Method, elem.Error)
+		}
+	}
+
+	var balanceHex, nonceHex string
+	if err := json.Unmarshal(batch[0].Result, &balanceHex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal balance: %w", err)
+	}
+	if err := json.Unmarshal(batch[1].Result, &nonceHex); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal nonce: %w", err)
+	}
+	balance, err := FromHex(balanceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance: %w", err)
+	}
+	nonce, err := hexToUint64(nonceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	code, err := decodeHexBytes(batch[2].Result)
+	if err != nil {
+		return nil, err
+	}
+	return newAddressInfo(e.client.formatAddress(address), balance, nonce, code), nil
+}
+
+func (e *Eth) inspectAddressSequential(ctx context.Context, address string) (*AddressInfo, error) {
+	balance, err := e.GetBalance(ctx, address, BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := e.GetTransactionCount(ctx, address, BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+	code, err := e.GetCode(ctx, address, BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+	return newAddressInfo(e.client.formatAddress(address), balance, nonce, code), nil
+}
+
+func newAddressInfo(address string, balance *big.Int, nonce uint64, code []byte) *AddressInfo {
+	return &AddressInfo{
+		Address:    address,
+		Balance:    balance,
+		Nonce:      nonce,
+		IsContract: len(code) > 0,
+		CodeSize:   len(code),
+	}
+}
+
+// GetNonceGap fetches the latest and pending transaction counts of address in
+// one batch. gap is the number of transactions the node holds in its pool but
+// that are not mined yet; a gap that does not shrink points at stuck
+// transactions.
+func (e *Eth) GetNonceGap(ctx context.Context, address string) (latest, pending, gap uint64, err error) {
+	batch := []BatchElem{
+		{Method: EthGetTransactionCount.String(), Params: []interface{}{address, BlockLatest.String()}},
+		{Method: EthGetTransactionCount.String(), Params: []interface{}{address, BlockPending.String()}},
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return 0, 0, 0, err
+		}
+		for i := range batch {
+			batch[i].Result, batch[i].Error = e.client.Call(ctx, batch[i].Method, batch[i].Params)
+		}
+	}
+
+	counts := make([]uint64, len(batch))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return 0, 0, 0, fmt.Errorf("failed to get %s nonce: %w", elem.Params[1], elem.Error)
+		}
+		var countHex string
+		if err := json.Unmarshal(elem.Result, &countHex); err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to unmarshal %s nonce: %w", elem.Params[1], err)
+		}
+		if counts[i], err = hexToUint64(countHex); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid %s nonce: %w", elem.Params[1], err)
+		}
+	}
+
+	latest, pending = counts[0], counts[1]
+	// Load-balanced nodes can briefly report pending below latest.
+	if pending > latest {
+		gap = pending - latest
+	}
+	return latest, pending, gap, nil
+}
+
+// GetStorageAt returns the 32-byte value of a contract storage slot.
+func (e *Eth) GetStorageAt(ctx context.Context, address string, slot *big.Int, blockNumber BlockParameter) ([]byte, error) {
+	if blockNumber == "" {
+		blockNumber = BlockLatest
+	}
+
+	result, err := e.client.Call(ctx, EthGetStorageAt.String(), []interface{}{address, ToHex(slot), blockNumber.String()})
+	if err != nil {
+		return nil, err
+	}
+	return decodeHexBytes(result)
+}
+
+// GetStorageAtMany reads several storage slots of a contract in a single
+// batch request. Values are returned in the order of slots.
+func (e *Eth) GetStorageAtMany(ctx context.Context, address string, slots []*big.Int, blockNumber BlockParameter) ([][]byte, error) {
+	if blockNumber == "" {
+		blockNumber = BlockLatest
+	}
+
+	batch := make([]BatchElem, len(slots))
+	for i, slot := range slots {
+		batch[i] = BatchElem{
+			Method: EthGetStorageAt.String(),
+			Params: []interface{}{address, ToHex(slot), blockNumber.String()},
+		}
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, len(slots))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to read slot %s: %w", slots[i], elem.Error)
+		}
+		value, err := decodeHexBytes(elem.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slot %s: %w", slots[i], err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodeHexBytes decodes a JSON hex data string result.
+func decodeHexBytes(result json.RawMessage) ([]byte, error) {
+	var data string
+	if err := json.Unmarshal(result, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	value, err := hex.DecodeString(StripHexPrefix(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex data: %w", err)
+	}
+	return value, nil
+}
+
+func isNullResult(result json.RawMessage) bool {
+	return len(result) == 0 || string(result) == "null"
+}
+
 // GetPendingTransactions returns pending transactions from the mempool
 func (e *Eth) GetPendingTransactions(ctx context.Context) ([]*Transaction, error) {
 	// Get the pending block with full transaction details
@@ -289,9 +1056,34 @@ func (e *Eth) GetPendingTransactions(ctx context.Context) ([]*Transaction, error
 			if gasPrice, ok := txData["gasPrice"].(string); ok {
 				tx.GasPrice = gasPrice
 			}
+			if maxFeePerGas, ok := txData["maxFeePerGas"].(string); ok {
+				tx.MaxFeePerGas = maxFeePerGas
+			}
+			if maxPriorityFeePerGas, ok := txData["maxPriorityFeePerGas"].(string); ok {
+				tx.MaxPriorityFeePerGas = maxPriorityFeePerGas
+			}
 			if input, ok := txData["input"].(string); ok {
 				tx.Input = input
+			} else if data, ok := txData["data"].(string); ok {
+				tx.Input = data
 			}
+			if txType, ok := txData["type"].(string); ok {
+				parsed, err := ParseTxType(txType)
+				if err != nil {
+					return nil, err
+				}
+				tx.Type = parsed
+			}
+			if accessList, ok := txData["accessList"]; ok && accessList != nil {
+				encoded, err := json.Marshal(accessList)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode access list: %w", err)
+				}
+				if err := json.Unmarshal(encoded, &tx.AccessList); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal access list: %w", err)
+				}
+			}
+			e.normalizeTransaction(tx)
 			
 			pendingTxs = append(pendingTxs, tx)
 		}
@@ -318,7 +1110,7 @@ func (e *Eth) GetAccountPendingTransactions(ctx context.Context, address string)
 	
 	var accountTxs []*Transaction
 	for _, tx := range allPendingTxs {
-		if tx.From == address || tx.To == address {
+		if strings.EqualFold(tx.From, address) || strings.EqualFold(tx.To, address) {
 			accountTxs = append(accountTxs, tx)
 		}
 	}
@@ -326,6 +1118,127 @@ func (e *Eth) GetAccountPendingTransactions(ctx context.Context, address string)
 	return accountTxs, nil
 }
 
+// GetTxPoolTransactions returns every transaction sent by address that is in
+// the node's transaction pool, both pending and queued (e.g. behind a nonce
+// gap). It uses txpool_contentFrom, falling back to the full txpool_content
+// on nodes that only have the latter. Nodes without the txpool namespace
+// return an error matching ErrMethodNotSupported.
+func (e *Eth) GetTxPoolTransactions(ctx context.Context, address string) ([]*Transaction, error) {
+	type nonceTxs map[string]*Transaction
+
+	content, err := CallInto[map[string]nonceTxs](ctx, e.client, TxpoolContentFrom.String(), []interface{}{address})
+	if err == nil {
+		var txs []*Transaction
+		for _, pool := range content {
+			for _, tx := range pool {
+				txs = append(txs, tx)
+			}
+		}
+		return txs, nil
+	}
+	if !errors.Is(err, ErrMethodNotSupported) {
+		return nil, err
+	}
+
+	full, err := CallInto[map[string]map[string]nonceTxs](ctx, e.client, TxpoolContent.String(), []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var txs []*Transaction
+	for _, pool := range full {
+		for sender, byNonce := range pool {
+			if !strings.EqualFold(sender, address) {
+				continue
+			}
+			for _, tx := range byNonce {
+				txs = append(txs, tx)
+			}
+		}
+	}
+	return txs, nil
+}
+
+// GetTransactionStatus returns the state of a single transaction; see
+// GetTransactionStatuses.
+func (e *Eth) GetTransactionStatus(ctx context.Context, txHash string) (TxState, error) {
+	states, err := e.GetTransactionStatuses(ctx, []string{txHash})
+	if err != nil {
+		return "", err
+	}
+	return states[txHash], nil
+}
+
+// GetTransactionStatuses classifies many transactions at once. Receipts for
+// all hashes are fetched in one batch; hashes without a receipt are then
+// looked up in a second batch to tell pending transactions from unknown ones.
+// Receipts without a status field (pre-Byzantium) count as TxStateSuccess.
+func (e *Eth) GetTransactionStatuses(ctx context.Context, hashes []string) (map[string]TxState, error) {
+	states := make(map[string]TxState, len(hashes))
+
+	receipts, err := e.batchLookup(ctx, EthGetTransactionReceipt, hashes)
+	if err != nil {
+		return nil, err
+	}
+	var unmined []string
+	for i, hash := range hashes {
+		receipt, err := e.decodeReceipt(receipts[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hash, err)
+		}
+		switch {
+		case receipt == nil:
+			unmined = append(unmined, hash)
+		case TxStatus(receipt.Status).IsFailure():
+			states[hash] = TxStateFailed
+		default:
+			states[hash] = TxStateSuccess
+		}
+	}
+	if len(unmined) == 0 {
+		return states, nil
+	}
+
+	txs, err := e.batchLookup(ctx, EthGetTransactionByHash, unmined)
+	if err != nil {
+		return nil, err
+	}
+	for i, hash := range unmined {
+		if isNullResult(txs[i]) {
+			states[hash] = TxStateUnknown
+		} else {
+			states[hash] = TxStatePending
+		}
+	}
+	return states, nil
+}
+
+// batchLookup calls method once per hash in a single batch, falling back to
+// separate calls if the node rejects batches, and returns the raw results in
+// order.
+func (e *Eth) batchLookup(ctx context.Context, method RPCMethod, hashes []string) ([]json.RawMessage, error) {
+	batch := make([]BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = BatchElem{Method: method.String(), Params: []interface{}{hash}}
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		for i := range batch {
+			batch[i].Result, batch[i].Error = e.client.Call(ctx, batch[i].Method, batch[i].Params)
+		}
+	}
+
+	results := make([]json.RawMessage, len(batch))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("%s %s failed: %w", method, hashes[i], elem.Error)
+		}
+		results[i] = elem.Result
+	}
+	return results, nil
+}
+
 // IsPendingTransaction checks if a transaction hash is in the pending pool
 func (e *Eth) IsPendingTransaction(ctx context.Context, txHash string) (bool, error) {
 	pendingTxs, err := e.GetPendingTransactions(ctx)
@@ -0,0 +1,73 @@
+package web3
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EventTopic returns the 0x-prefixed topic0 for an event signature such as
+// "Transfer(address,address,uint256)".
+func EventTopic(eventSignature string) string {
+	return crypto.Keccak256Hash([]byte(eventSignature)).Hex()
+}
+
+// EncodeTopic ABI-encodes an indexed event argument into a 32-byte topic.
+//
+// Addresses are left-padded, integers are encoded as two's complement words
+// and booleans as 0 or 1. A 32-byte hex string (0x prefix optional), [32]byte
+// or common.Hash is used as-is. A []byte is treated as an indexed dynamic
+// bytes/string value and is therefore hashed, as the EVM does when emitting it.
+func EncodeTopic(value interface{}) (string, error) {
+	var word common.Hash
+
+	switch v := value.(type) {
+	case string:
+		digits := StripHexPrefix(v)
+		switch {
+		case IsAddress(EnsureHexPrefix(v)):
+			word = common.BytesToHash(common.HexToAddress(digits).Bytes())
+		case len(digits) == 64:
+			decoded, err := hex.DecodeString(digits)
+			if err != nil {
+				return "", fmt.Errorf("invalid topic value %q: %w", v, err)
+			}
+			word = common.BytesToHash(decoded)
+		default:
+			return "", fmt.Errorf("string topic value must be an address or 32-byte hex, got %q", v)
+		}
+	case common.Address:
+		word = common.BytesToHash(v.Bytes())
+	case common.Hash:
+		word = v
+	case [32]byte:
+		word = v
+	case []byte:
+		word = crypto.Keccak256Hash(v)
+	case bool:
+		if v {
+			word[31] = 1
+		}
+	case *big.Int:
+		if v == nil {
+			return "", fmt.Errorf("topic value is nil")
+		}
+		word = common.BytesToHash(math.U256Bytes(new(big.Int).Set(v)))
+	case int:
+		word = common.BytesToHash(math.U256Bytes(big.NewInt(int64(v))))
+	case int64:
+		word = common.BytesToHash(math.U256Bytes(big.NewInt(v)))
+	case uint64:
+		word = common.BytesToHash(new(big.Int).SetUint64(v).Bytes())
+	case uint8:
+		word[31] = v
+	default:
+		return "", fmt.Errorf("unsupported topic value type: %T", value)
+	}
+
+	return word.Hex(), nil
+}
@@ -192,12 +192,16 @@ func blockchainHelperExample() {
 	fmt.Println("\n10. Simplified Transaction Building:")
 
 	// Simple ETH transfer
-	simpleTransfer := web3.NewSimpleTransfer(
+	simpleTransfer, err := web3.NewSimpleTransfer(
 		"0xRecipient",
 		"1.0",
 		web3.ChainMainnet,
 	)
-	fmt.Printf("   Simple transfer gas limit: %d\n", simpleTransfer.Gas)
+	if err != nil {
+		log.Printf("Error creating simple transfer: %v", err)
+	} else {
+		fmt.Printf("   Simple transfer gas limit: %d\n", simpleTransfer.Gas)
+	}
 
 	// Token transfer transaction
 	tokenTransferTx, err := web3.NewTokenTransfer(
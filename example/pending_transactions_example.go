@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 
 	"github.com/donghquinn/go-web3"
 )
@@ -45,8 +46,7 @@ func pendingTransactionsExample() {
 		if tx.GasPrice != "" && tx.GasPrice != "0x0" {
 			gasPriceWei, parseErr := web3.FromHex(tx.GasPrice)
 			if parseErr == nil {
-				gasPriceGwei, _ := web3.WeiToGwei(gasPriceWei)
-				fmt.Printf("     Gas Price: %s Gwei\n", gasPriceGwei)
+				fmt.Printf("     Gas Price: %s\n", web3.FormatGasPrice(gasPriceWei))
 			}
 		}
 		fmt.Println()
@@ -108,25 +108,22 @@ func pendingTransactionsExample() {
 	fmt.Println("\n5. Analyzing gas prices in pending transactions...")
 	
 	if len(pendingTxs) > 0 {
-		var totalGasPrice float64
+		totalGasPrice := big.NewInt(0)
 		var validTxCount int
 		
 		for _, tx := range pendingTxs {
 			if tx.GasPrice != "" && tx.GasPrice != "0x0" {
 				gasPriceWei, parseErr := web3.FromHex(tx.GasPrice)
 				if parseErr == nil {
-					gasPriceGwei, _ := web3.WeiToGwei(gasPriceWei)
-					if gasPriceFloat, parseErr2 := parseFloat(gasPriceGwei); parseErr2 == nil {
-						totalGasPrice += gasPriceFloat
-						validTxCount++
-					}
+					totalGasPrice.Add(totalGasPrice, gasPriceWei)
+					validTxCount++
 				}
 			}
 		}
 		
 		if validTxCount > 0 {
-			avgGasPrice := totalGasPrice / float64(validTxCount)
-			fmt.Printf("   Average gas price in pending pool: %.2f Gwei\n", avgGasPrice)
+			avgGasPrice := new(big.Int).Div(totalGasPrice, big.NewInt(int64(validTxCount)))
+			fmt.Printf("   Average gas price in pending pool: %s\n", web3.FormatGasPrice(avgGasPrice))
 			fmt.Printf("   Based on %d transactions with valid gas prices\n", validTxCount)
 		}
 	}
@@ -177,10 +174,3 @@ func getPendingGasPriceLevelName(level web3.GasPriceLevel) string {
 		return "Unknown "
 	}
 }
-
-// Helper function to parse float from string
-func parseFloat(s string) (float64, error) {
-	var f float64
-	_, err := fmt.Sscanf(s, "%f", &f)
-	return f, err
-}
\ No newline at end of file
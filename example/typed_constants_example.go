@@ -110,13 +110,15 @@ func typedConstantsExample() {
 	fmt.Println("\n6. Transaction Builder Helpers:")
 
 	// Simple ETH transfer
-	ethTransfer := web3.NewSimpleTransfer(
+	ethTransfer, err := web3.NewSimpleTransfer(
 		"0xRecipientAddress",
 		"0.1",
 		web3.ChainMainnet,
 	)
-	fmt.Printf("   ETH Transfer - Gas: %d, Chain: %d\n",
-		ethTransfer.Gas, ethTransfer.ChainID.Uint64())
+	if err == nil {
+		fmt.Printf("   ETH Transfer - Gas: %d, Chain: %d\n",
+			ethTransfer.Gas, ethTransfer.ChainID.Uint64())
+	}
 
 	// Token transfer
 	tokenAmount := big.NewInt(1000000000000000000) // 1 token with 18 decimals
@@ -0,0 +1,222 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Explorer is a client for an Etherscan-compatible block explorer API.
+type Explorer struct {
+	apiURL     string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type explorerResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// NewExplorer creates an explorer client for a network registered in
+// Networks, using its ExplorerAPI endpoint.
+func NewExplorer(chainID ChainID, apiKey string) (*Explorer, error) {
+	config, err := GetNetworkConfig(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if config.ExplorerAPI == "" {
+		return nil, fmt.Errorf("no explorer API configured for chain ID %d", chainID)
+	}
+	return NewExplorerWithURL(config.ExplorerAPI, apiKey), nil
+}
+
+// NewExplorerWithURL creates an explorer client for an arbitrary
+// Etherscan-compatible API endpoint, e.g. "https://api.etherscan.io/api".
+func NewExplorerWithURL(apiURL, apiKey string) *Explorer {
+	return &Explorer{
+		apiURL:     apiURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+	}
+}
+
+// GetContractABI returns the ABI JSON of a verified contract.
+func (exp *Explorer) GetContractABI(ctx context.Context, address string) (string, error) {
+	resp, err := exp.get(ctx, url.Values{
+		"module":  {"contract"},
+		"action":  {"getabi"},
+		"address": {address},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal explorer result: %w", err)
+	}
+	if resp.Status != "1" {
+		return "", fmt.Errorf("explorer error: %s: %s", resp.Message, result)
+	}
+	return result, nil
+}
+
+// IsVerified reports whether the contract's source is verified on the
+// explorer, based on the SourceCode field of contract/getsourcecode, which is
+// empty for unverified contracts. API failures such as an invalid key or a
+// rate limit are returned as errors.
+func (exp *Explorer) IsVerified(ctx context.Context, address string) (bool, error) {
+	resp, err := exp.get(ctx, url.Values{
+		"module":  {"contract"},
+		"action":  {"getsourcecode"},
+		"address": {address},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if resp.Status != "1" {
+		var result string
+		json.Unmarshal(resp.Result, &result)
+		return false, fmt.Errorf("explorer error: %s: %s", resp.Message, result)
+	}
+
+	var sources []struct {
+		SourceCode string `json:"SourceCode"`
+	}
+	if err := json.Unmarshal(resp.Result, &sources); err != nil {
+		return false, fmt.Errorf("failed to unmarshal explorer result: %w", err)
+	}
+	if len(sources) == 0 {
+		return false, fmt.Errorf("explorer returned no source entry for %s", address)
+	}
+	return sources[0].SourceCode != "", nil
+}
+
+// explorerTransaction is a transaction as listed by account/txlist, with
+// quantities in decimal.
+type explorerTransaction struct {
+	Hash             string `json:"hash"`
+	Nonce            string `json:"nonce"`
+	BlockHash        string `json:"blockHash"`
+	BlockNumber      string `json:"blockNumber"`
+	TransactionIndex string `json:"transactionIndex"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	Gas              string `json:"gas"`
+	GasPrice         string `json:"gasPrice"`
+	Input            string `json:"input"`
+}
+
+// GetAddressTransactions returns one page of the normal transactions sent
+// from or to address, oldest first. page starts at 1 and offset is the page
+// size. Quantities are converted to hex as returned by the node.
+func (exp *Explorer) GetAddressTransactions(ctx context.Context, address string, page, offset int) ([]Transaction, error) {
+	resp, err := exp.get(ctx, url.Values{
+		"module":  {"account"},
+		"action":  {"txlist"},
+		"address": {address},
+		"page":    {strconv.Itoa(page)},
+		"offset":  {strconv.Itoa(offset)},
+		"sort":    {"asc"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != "1" {
+		if strings.Contains(strings.ToLower(resp.Message), "no transactions found") {
+			return []Transaction{}, nil
+		}
+		var result string
+		json.Unmarshal(resp.Result, &result)
+		return nil, fmt.Errorf("explorer error: %s: %s", resp.Message, result)
+	}
+
+	var listed []explorerTransaction
+	if err := json.Unmarshal(resp.Result, &listed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal explorer result: %w", err)
+	}
+
+	txs := make([]Transaction, len(listed))
+	for i, tx := range listed {
+		txs[i] = Transaction{
+			Hash:      tx.Hash,
+			BlockHash: tx.BlockHash,
+			From:      tx.From,
+			To:        tx.To,
+			Input:     tx.Input,
+		}
+		for _, field := range []struct {
+			name     string
+			decimal  string
+			quantity *string
+		}{
+			{"nonce", tx.Nonce, &txs[i].Nonce},
+			{"blockNumber", tx.BlockNumber, &txs[i].BlockNumber},
+			{"transactionIndex", tx.TransactionIndex, &txs[i].TransactionIndex},
+			{"value", tx.Value, &txs[i].Value},
+			{"gas", tx.Gas, &txs[i].Gas},
+			{"gasPrice", tx.GasPrice, &txs[i].GasPrice},
+		} {
+			quantity, err := decimalToHex(field.decimal)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s of transaction %s: %w", field.name, tx.Hash, err)
+			}
+			*field.quantity = quantity
+		}
+	}
+	return txs, nil
+}
+
+// decimalToHex converts a decimal quantity from the explorer API to the hex
+// form used by the node. An empty value stays empty.
+func decimalToHex(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	n, err := parseDecimalAmount(value, 0)
+	if err != nil || n.Sign() < 0 {
+		return "", fmt.Errorf("invalid decimal quantity %q", value)
+	}
+	return ToHex(n), nil
+}
+
+func (exp *Explorer) get(ctx context.Context, params url.Values) (*explorerResponse, error) {
+	if exp.apiKey != "" {
+		params.Set("apikey", exp.apiKey)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", exp.apiURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := exp.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newHTTPError(resp, body)
+	}
+
+	var explorerResp explorerResponse
+	if err := json.Unmarshal(body, &explorerResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal explorer response: %w", err)
+	}
+	return &explorerResp, nil
+}
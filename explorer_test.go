@@ -0,0 +1,79 @@
+package web3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecimalToHex(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"0", "0x0", false},
+		{"21000", "0x5208", false},
+		{"1000000000000000000", "0xde0b6b3a7640000", false},
+		{"abc", "", true},
+		{"1e3", "", true},
+		{"Inf", "", true},
+		{"1.2.3", "", true},
+		{"1.5", "", true},
+		{"0x10", "", true},
+		{"-1", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := decimalToHex(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("decimalToHex(%q) = %s, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("decimalToHex(%q) error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("decimalToHex(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestExplorerIsVerified(t *testing.T) {
+	responses := map[string]string{
+		"0x01": `{"status":"1","message":"OK","result":[{"SourceCode":"pragma solidity ^0.8.0;","ABI":"[]","ContractName":"Token"}]}`,
+		"0x02": `{"status":"1","message":"OK","result":[{"SourceCode":"","ABI":"Contract source code not verified","ContractName":""}]}`,
+		"0x03": `{"status":"0","message":"NOTOK","result":"Invalid API Key"}`,
+		"0x04": `{"status":"1","message":"OK","result":[]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("action"); got != "getsourcecode" {
+			t.Errorf("action = %s, want getsourcecode", got)
+		}
+		w.Write([]byte(responses[r.URL.Query().Get("address")]))
+	}))
+	defer server.Close()
+	exp := NewExplorerWithURL(server.URL, "key")
+
+	tests := []struct {
+		address  string
+		verified bool
+		wantErr  bool
+	}{
+		{"0x01", true, false},
+		{"0x02", false, false},
+		{"0x03", false, true},
+		{"0x04", false, true},
+	}
+	for _, tt := range tests {
+		verified, err := exp.IsVerified(context.Background(), tt.address)
+		if (err != nil) != tt.wantErr || verified != tt.verified {
+			t.Errorf("IsVerified(%s) = %v, %v; want %v, error %v", tt.address, verified, err, tt.verified, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package web3
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// ExportFormat selects the output format of ExportTransactions.
+type ExportFormat int
+
+const (
+	ExportJSONLines ExportFormat = iota // one JSON object per line
+	ExportCSV                           // CSV with a header row
+)
+
+// exportConcurrency caps the number of blocks fetched at the same time.
+const exportConcurrency = 8
+
+// ExportedTransaction is one row of ExportTransactions. Quantities are
+// decimal strings.
+type ExportedTransaction struct {
+	BlockNumber      uint64 `json:"blockNumber"`
+	BlockTimestamp   uint64 `json:"blockTimestamp"`
+	Hash             string `json:"hash"`
+	TransactionIndex uint64 `json:"transactionIndex"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	Gas              uint64 `json:"gas"`
+	GasPrice         string `json:"gasPrice"`
+	Nonce            uint64 `json:"nonce"`
+	Type             uint8  `json:"type"`
+	Input            string `json:"input"`
+}
+
+var exportCSVHeader = []string{
+	"blockNumber", "blockTimestamp", "hash", "transactionIndex", "from", "to",
+	"value", "gas", "gasPrice", "nonce", "type", "input",
+}
+
+func (t *ExportedTransaction) csvRecord() []string {
+	return []string{
+		strconv.FormatUint(t.BlockNumber, 10),
+		strconv.FormatUint(t.BlockTimestamp, 10),
+		t.Hash,
+		strconv.FormatUint(t.TransactionIndex, 10),
+		t.From,
+		t.To,
+		t.Value,
+		strconv.FormatUint(t.Gas, 10),
+		t.GasPrice,
+		strconv.FormatUint(t.Nonce, 10),
+		strconv.FormatUint(uint64(t.Type), 10),
+		t.Input,
+	}
+}
+
+// ExportTransactions streams every transaction of blocks from through to,
+// inclusive, to w in block and index order. Blocks are fetched concurrently
+// in windows of a few blocks, and the next window is only fetched once the
+// previous one has been written, so a slow writer throttles the export
+// instead of buffering the whole range in memory.
+func ExportTransactions(ctx context.Context, client *Client, from, to uint64, w io.Writer, format ExportFormat) error {
+	if from > to {
+		return fmt.Errorf("invalid block range: from %d > to %d", from, to)
+	}
+
+	var writeRow func(*ExportedTransaction) error
+	var flush func() error
+	switch format {
+	case ExportJSONLines:
+		encoder := json.NewEncoder(w)
+		writeRow = func(t *ExportedTransaction) error { return encoder.Encode(t) }
+		flush = func() error { return nil }
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportCSVHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		writeRow = func(t *ExportedTransaction) error { return writer.Write(t.csvRecord()) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return fmt.Errorf("unknown export format %d", format)
+	}
+
+	eth := client.Eth()
+	for start := from; start <= to; start += exportConcurrency {
+		end := min(start+exportConcurrency-1, to)
+		blocks := make([][]ExportedTransaction, end-start+1)
+		errs := make([]error, len(blocks))
+
+		var wg sync.WaitGroup
+		for i := range blocks {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				blocks[i], errs[i] = eth.exportBlock(ctx, start+uint64(i))
+			}(i)
+		}
+		wg.Wait()
+
+		for i, rows := range blocks {
+			if errs[i] != nil {
+				return errs[i]
+			}
+			for j := range rows {
+				if err := writeRow(&rows[j]); err != nil {
+					return fmt.Errorf("failed to write transaction %s: %w", rows[j].Hash, err)
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return fmt.Errorf("failed to flush export: %w", err)
+		}
+		if end == to {
+			break
+		}
+	}
+	return nil
+}
+
+// exportBlock fetches a block with full transactions and flattens them.
+func (e *Eth) exportBlock(ctx context.Context, number uint64) ([]ExportedTransaction, error) {
+	result, err := e.client.Call(ctx, EthGetBlockByNumber.String(), []interface{}{BlockNumber(number).String(), true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+	if isNullResult(result) {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	var block struct {
+		Timestamp    string        `json:"timestamp"`
+		Transactions []Transaction `json:"transactions"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block %d: %w", number, err)
+	}
+	timestamp, err := hexToUint64(block.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp of block %d: %w", number, err)
+	}
+
+	rows := make([]ExportedTransaction, len(block.Transactions))
+	for i := range block.Transactions {
+		tx := &block.Transactions[i]
+		e.normalizeTransaction(tx)
+		row := ExportedTransaction{
+			BlockNumber:    number,
+			BlockTimestamp: timestamp,
+			Hash:           tx.Hash,
+			From:           tx.From,
+			To:             tx.To,
+			Type:           uint8(tx.Type),
+			Input:          tx.Input,
+		}
+		if err := parseExportQuantities(tx, &row); err != nil {
+			return nil, fmt.Errorf("invalid transaction %s: %w", tx.Hash, err)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func parseExportQuantities(tx *Transaction, row *ExportedTransaction) error {
+	var err error
+	if row.TransactionIndex, err = hexToUint64(tx.TransactionIndex); err != nil {
+		return err
+	}
+	if row.Gas, err = hexToUint64(tx.Gas); err != nil {
+		return err
+	}
+	if row.Nonce, err = hexToUint64(tx.Nonce); err != nil {
+		return err
+	}
+	value, err := FromHex(tx.Value)
+	if err != nil {
+		return err
+	}
+	row.Value = value.String()
+	if tx.GasPrice != "" {
+		gasPrice, err := FromHex(tx.GasPrice)
+		if err != nil {
+			return err
+		}
+		row.GasPrice = gasPrice.String()
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package web3
+
+import (
+	"math/big"
+	"sync"
+)
+
+// GasProfile holds the absolute priority fee (tip), in wei, used for each
+// gas price level on a chain.
+type GasProfile struct {
+	Slow     *big.Int
+	Standard *big.Int
+	Fast     *big.Int
+	Rapid    *big.Int
+}
+
+func (gp GasProfile) tip(level GasPriceLevel) *big.Int {
+	switch level {
+	case GasPriceSlow:
+		return gp.Slow
+	case GasPriceFast:
+		return gp.Fast
+	case GasPriceRapid:
+		return gp.Rapid
+	default:
+		return gp.Standard
+	}
+}
+
+// defaultGasProfile applies to chains without a registered profile.
+var defaultGasProfile = GasProfile{
+	Slow:     big.NewInt(1e9),
+	Standard: big.NewInt(15e8),
+	Fast:     big.NewInt(2e9),
+	Rapid:    big.NewInt(3e9),
+}
+
+var (
+	gasProfilesMu sync.RWMutex
+	gasProfiles   = map[ChainID]GasProfile{
+		// OP-stack chains run with a near-zero base fee and tiny tips.
+		ChainOptimism:       {Slow: big.NewInt(1e6), Standard: big.NewInt(1e6), Fast: big.NewInt(1e7), Rapid: big.NewInt(1e8)},
+		ChainOptimismGoerli: {Slow: big.NewInt(1e6), Standard: big.NewInt(1e6), Fast: big.NewInt(1e7), Rapid: big.NewInt(1e8)},
+		// Arbitrum ignores the priority fee.
+		ChainArbitrum:       {Slow: big.NewInt(0), Standard: big.NewInt(0), Fast: big.NewInt(0), Rapid: big.NewInt(0)},
+		ChainArbitrumGoerli: {Slow: big.NewInt(0), Standard: big.NewInt(0), Fast: big.NewInt(0), Rapid: big.NewInt(0)},
+		// Polygon enforces a 30 gwei minimum tip.
+		ChainPolygon: {Slow: big.NewInt(30e9), Standard: big.NewInt(35e9), Fast: big.NewInt(40e9), Rapid: big.NewInt(50e9)},
+	}
+)
+
+// RegisterGasProfile sets the tips used by GasPriceLevel.AbsoluteTip for a
+// chain, replacing any existing profile. Nil fields fall back to the
+// default profile. It is safe for concurrent use.
+func RegisterGasProfile(chainID ChainID, profile GasProfile) {
+	gasProfilesMu.Lock()
+	gasProfiles[chainID] = profile
+	gasProfilesMu.Unlock()
+}
+
+// AbsoluteTip returns the priority fee, in wei, for the level on chainID,
+// for chains where scaling the gas price with Multiplier gives unreasonable
+// fees.
+func (gpl GasPriceLevel) AbsoluteTip(chainID ChainID) *big.Int {
+	gasProfilesMu.RLock()
+	profile, ok := gasProfiles[chainID]
+	gasProfilesMu.RUnlock()
+
+	var tip *big.Int
+	if ok {
+		tip = profile.tip(gpl)
+	}
+	if tip == nil {
+		tip = defaultGasProfile.tip(gpl)
+	}
+	return new(big.Int).Set(tip)
+}
@@ -2,8 +2,11 @@ package web3
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 
 	blockchainhelper "github.com/donghquinn/go-blockchain-helper/pkg/web3"
 )
@@ -25,7 +28,7 @@ func GetOptimalGasPrice(ctx context.Context, client *Client, level GasPriceLevel
 }
 
 // Enhanced gas estimation using go-blockchain-helper
-func EstimateGasWithBuffer(ctx context.Context, client *Client, tx map[string]interface{}, buffer float64) (uint64, error) {
+func EstimateGasWithBuffer(ctx context.Context, client *Client, tx *CallObject, buffer float64) (uint64, error) {
 	baseEstimate, err := client.Eth().EstimateGas(ctx, tx)
 	if err != nil {
 		return 0, err
@@ -35,10 +38,95 @@ func EstimateGasWithBuffer(ctx context.Context, client *Client, tx map[string]in
 	return baseEstimate + uint64(bufferAmount), nil
 }
 
+// GasEstimateOptions tunes EstimateGasSafe. MinGas is a floor applied after
+// buffering (e.g. GasLimitTransfer for plain transfers), BufferPercent pads
+// the node's estimate, and IncludeAccessList estimates with the access list
+// returned by eth_createAccessList attached.
+type GasEstimateOptions struct {
+	MinGas            uint64
+	BufferPercent     float64
+	IncludeAccessList bool
+}
+
+// GasEstimate is the result of EstimateGasSafe. AccessList is set when the
+// estimate was made with an access list, which must then be sent with the
+// transaction for the gas limit to hold.
+type GasEstimate struct {
+	GasLimit   uint64
+	AccessList []AccessTuple
+}
+
+// EstimateGasSafe estimates the gas limit of tx with a configurable buffer,
+// floor and optional access list. Set the 1559 fee fields on tx to have the
+// node estimate it as a type-2 transaction.
+func EstimateGasSafe(ctx context.Context, client *Client, tx *CallObject, opts GasEstimateOptions) (*GasEstimate, error) {
+	callObj := *tx
+	estimate := &GasEstimate{}
+
+	if opts.IncludeAccessList {
+		accessList, err := client.Eth().CreateAccessList(ctx, &callObj, BlockLatest)
+		if err != nil {
+			return nil, err
+		}
+		callObj.AccessList = accessList.AccessList
+		estimate.AccessList = accessList.AccessList
+	}
+
+	baseEstimate, err := client.Eth().EstimateGas(ctx, &callObj)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate.GasLimit = baseEstimate + uint64(float64(baseEstimate)*opts.BufferPercent/100)
+	if estimate.GasLimit < opts.MinGas {
+		estimate.GasLimit = opts.MinGas
+	}
+
+	return estimate, nil
+}
+
+// GasEfficiency returns the fraction of originalGasLimit the transaction
+// actually used, e.g. 0.8 for 80%. It returns 0 if originalGasLimit is 0 or
+// the receipt has no valid gasUsed.
+func (r *TransactionReceipt) GasEfficiency(originalGasLimit uint64) float64 {
+	if originalGasLimit == 0 {
+		return 0
+	}
+	gasUsed, err := hexToUint64(r.GasUsed)
+	if err != nil {
+		return 0
+	}
+	return float64(gasUsed) / float64(originalGasLimit)
+}
+
+// gasBufferSafetyMargin is added on top of the worst observed usage by
+// RecommendGasBuffer.
+const gasBufferSafetyMargin = 0.05
+
+// RecommendGasBuffer suggests a GasEstimateOptions.BufferPercent from the
+// GasEfficiency of past transactions whose limits were padded by
+// currentBufferPercent over the node's estimate. It covers the largest
+// observed usage relative to the estimate plus a 5% safety margin, so
+// consistently low efficiencies yield a tighter buffer and efficiencies near
+// 1 (or out-of-gas failures) a wider one.
+func RecommendGasBuffer(efficiencies []float64, currentBufferPercent float64) (float64, error) {
+	if len(efficiencies) == 0 {
+		return 0, fmt.Errorf("no samples")
+	}
+
+	worst := 0.0
+	for _, efficiency := range efficiencies {
+		if efficiency <= 0 || efficiency > 1 {
+			return 0, fmt.Errorf("invalid gas efficiency %v", efficiency)
+		}
+		worst = max(worst, efficiency*(1+currentBufferPercent/100))
+	}
+	return max(0, (worst*(1+gasBufferSafetyMargin)-1)*100), nil
+}
+
 // Unit conversion helpers using go-blockchain-helper
 func EtherToWei(ether string) (*big.Int, error) {
-	// Parse the ether string as float64 first
-	return blockchainhelper.ParseEther(ether)
+	return ParseEther(ether)
 }
 
 func WeiToEther(wei *big.Int) (string, error) {
@@ -48,7 +136,7 @@ func WeiToEther(wei *big.Int) (string, error) {
 }
 
 func GweiToWei(gwei string) (*big.Int, error) {
-	return blockchainhelper.ParseUnits(gwei, 9) // Gwei has 9 decimals
+	return ParseUnits(gwei, 9) // Gwei has 9 decimals
 }
 
 func WeiToGwei(wei *big.Int) (string, error) {
@@ -56,17 +144,58 @@ func WeiToGwei(wei *big.Int) (string, error) {
 	return result, nil
 }
 
-// Enhanced unit conversion with go-blockchain-helper
+// FormatGasPrice formats a gas price for display, e.g. "23.45 Gwei".
+func FormatGasPrice(wei *big.Int) string {
+	return formatRounded(wei, 9, 2) + " Gwei"
+}
+
+// FormatFee formats a fee or other ether amount for display, e.g. "0.0012 ETH".
+func FormatFee(wei *big.Int) string {
+	return formatRounded(wei, 18, 4) + " ETH"
+}
+
+// FormatFeeForChain formats a fee in the native gas token of chainID, e.g.
+// "0.01 MATIC" on Polygon.
+func FormatFeeForChain(wei *big.Int, chainID ChainID) string {
+	return formatRounded(wei, 18, 4) + " " + chainID.Currency()
+}
+
+// formatRounded renders value / 10^decimals rounded to places digits, trimming
+// trailing zeros. Non-zero values too small to show are rendered as "<0.0001".
+func formatRounded(value *big.Int, decimals, places int) string {
+	if value == nil {
+		return "0"
+	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	amount := new(big.Rat).SetFrac(value, divisor)
+
+	text := amount.FloatString(places)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	if (text == "0" || text == "-0") && value.Sign() != 0 {
+		return "<0." + strings.Repeat("0", places-1) + "1"
+	}
+	return text
+}
+
+// ParseEther converts a plain decimal ether amount to wei exactly; more than
+// 18 decimal places is an error rather than being truncated.
 func ParseEther(ether string) (*big.Int, error) {
-	return blockchainhelper.ParseEther(ether)
+	return parseDecimalAmount(ether, 18)
 }
 
 func FormatEther(wei *big.Int, decimals int) string {
 	return blockchainhelper.FormatEther(wei, decimals)
 }
 
+// ParseUnits converts a plain decimal amount to its base units, value *
+// 10^decimals, exactly; more than decimals decimal places is an error.
 func ParseUnits(value string, decimals int) (*big.Int, error) {
-	return blockchainhelper.ParseUnits(value, decimals)
+	if decimals < 0 {
+		return nil, fmt.Errorf("invalid decimals %d", decimals)
+	}
+	return parseDecimalAmount(value, decimals)
 }
 
 func FormatUnits(value *big.Int, decimals int) string {
@@ -101,14 +230,66 @@ func IsMainnet(chainID ChainID) bool {
 	return chainID == ChainMainnet
 }
 
+// ParseChainAddress parses an EIP-3770 chain-specific address such as
+// "matic:0xabc..." into its chain ID and bare address.
+func ParseChainAddress(s string) (ChainID, string, error) {
+	shortName, address, found := strings.Cut(s, ":")
+	if !found {
+		return 0, "", fmt.Errorf("missing chain prefix in %q", s)
+	}
+	if !IsAddress(address) {
+		return 0, "", fmt.Errorf("invalid address: %s", address)
+	}
+
+	for chainID, name := range chainShortNames {
+		if strings.EqualFold(name, shortName) {
+			return chainID, address, nil
+		}
+	}
+	return 0, "", fmt.Errorf("unknown chain short name %q", shortName)
+}
+
+// FormatChainAddress formats an address as an EIP-3770 chain-specific
+// address, e.g. "eth:0xd8dA...6045".
+func FormatChainAddress(chainID ChainID, address string) (string, error) {
+	shortName, ok := chainID.ShortName()
+	if !ok {
+		return "", fmt.Errorf("no short name known for chain ID %d", chainID)
+	}
+	if !IsAddress(address) {
+		return "", fmt.Errorf("invalid address: %s", address)
+	}
+	return shortName + ":" + address, nil
+}
+
 // Transaction helpers using go-blockchain-helper
-func NewSimpleTransfer(to string, amountEth string, chainID ChainID) *TransactionParams {
-	value, _ := EtherToWei(amountEth)
+// NewSimpleTransfer builds a legacy ETH transfer. The gas price is left unset.
+// Prefer NewSimpleTransfer1559 on chains that support EIP-1559.
+func NewSimpleTransfer(to string, amountEth string, chainID ChainID) (*TransactionParams, error) {
+	value, err := EtherToWei(amountEth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ether amount %q: %w", amountEth, err)
+	}
 	return NewTransactionParams().
 		SetTo(to).
 		SetValue(value).
 		SetGas(GasLimitTransfer.Uint64()).
-		SetChainID(chainID)
+		SetChainID(chainID), nil
+}
+
+// NewSimpleTransfer1559 builds an EIP-1559 ETH transfer. The fee fields are
+// left unset.
+func NewSimpleTransfer1559(to string, amountEth string, chainID ChainID) (*EIP1559TransactionParams, error) {
+	value, err := EtherToWei(amountEth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ether amount %q: %w", amountEth, err)
+	}
+	tx := NewEIP1559TransactionParams()
+	tx.To = to
+	tx.Value = value
+	tx.Gas = GasLimitTransfer.Uint64()
+	tx.ChainID = chainID.BigInt()
+	return tx, nil
 }
 
 // Enhanced transaction creation using go-blockchain-helper
@@ -178,6 +359,56 @@ func NewTokenApproval(tokenContract, spender string, amount *big.Int, chainID Ch
 		SetChainID(chainID), nil
 }
 
+// CallContract executes a read-only call of data against contract at block.
+// from sets msg.sender for contracts whose view functions depend on the
+// caller; leave it empty to omit the field.
+func CallContract(ctx context.Context, client *Client, contract string, data []byte, from string, block BlockParameter) (string, error) {
+	callObj := NewCallObject(contract).SetData(data)
+	if from != "" {
+		callObj.SetFrom(from)
+	}
+	return client.Eth().Call(ctx, callObj, block)
+}
+
+// CallMultiBlock executes the same eth_call at each of blocks using batched
+// requests, returning the results in the order of blocks. If the node does
+// not support batches it falls back to one call per block.
+func CallMultiBlock(ctx context.Context, client *Client, callObj map[string]interface{}, blocks []BlockParameter) ([]string, error) {
+	batch := make([]BatchElem, len(blocks))
+	for i, block := range blocks {
+		if block == "" {
+			block = BlockLatest
+		}
+		batch[i] = BatchElem{
+			Method: EthCall.String(),
+			Params: []interface{}{callObj, block.String()},
+		}
+	}
+
+	for start := 0; start < len(batch); start += maxScanBatchSize {
+		chunk := batch[start:min(start+maxScanBatchSize, len(batch))]
+		if err := client.BatchCall(ctx, chunk); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			for i := range chunk {
+				chunk[i].Result, chunk[i].Error = client.Call(ctx, chunk[i].Method, chunk[i].Params)
+			}
+		}
+	}
+
+	results := make([]string, len(blocks))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("call at block %s failed: %w", blocks[i], elem.Error)
+		}
+		if err := json.Unmarshal(elem.Result, &results[i]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal call result at block %s: %w", blocks[i], err)
+		}
+	}
+	return results, nil
+}
+
 // Enhanced contract interaction using go-blockchain-helper
 func GetTokenBalance(ctx context.Context, client *Client, tokenContract, address string) (*big.Int, error) {
 	token := blockchainhelper.NewERC20Token(tokenContract, "Token", "TKN", 18)
@@ -186,12 +417,7 @@ func GetTokenBalance(ctx context.Context, client *Client, tokenContract, address
 		return nil, err
 	}
 	
-	callObj := map[string]interface{}{
-		"to":   tokenContract,
-		"data": fmt.Sprintf("0x%x", data),
-	}
-	
-	result, err := client.Eth().Call(ctx, callObj, BlockLatest)
+	result, err := client.Eth().Call(ctx, NewCallObject(tokenContract).SetData(data), BlockLatest)
 	if err != nil {
 		return nil, err
 	}
@@ -206,12 +432,7 @@ func GetTokenAllowance(ctx context.Context, client *Client, tokenContract, owner
 		return nil, err
 	}
 	
-	callObj := map[string]interface{}{
-		"to":   tokenContract,
-		"data": fmt.Sprintf("0x%x", data),
-	}
-	
-	result, err := client.Eth().Call(ctx, callObj, BlockLatest)
+	result, err := client.Eth().Call(ctx, NewCallObject(tokenContract).SetData(data), BlockLatest)
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +440,54 @@ func GetTokenAllowance(ctx context.Context, client *Client, tokenContract, owner
 	return FromHex(result)
 }
 
+// GetTokenDecimals reads the ERC-20 decimals() value of a token contract.
+func GetTokenDecimals(ctx context.Context, client *Client, tokenContract string) (uint8, error) {
+	result, err := client.Eth().Call(ctx, NewCallObject(tokenContract).SetData(FuncDecimals.Selector()), BlockLatest)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTokenDecimals(result)
+}
+
+// parseTokenDecimals parses the hex result of a decimals() call.
+func parseTokenDecimals(result string) (uint8, error) {
+	if StripHexPrefix(result) == "" {
+		return 0, fmt.Errorf("decimals() returned no data")
+	}
+	decimals, err := FromHex(result)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse decimals: %w", err)
+	}
+	if !decimals.IsUint64() || decimals.Uint64() > 255 {
+		return 0, fmt.Errorf("decimals out of range: %s", decimals)
+	}
+	return uint8(decimals.Uint64()), nil
+}
+
+// GetTokenSymbol reads the ERC-20 symbol() value of a token contract. Tokens
+// that return a bytes32 symbol instead of a string (e.g. MKR) are supported.
+func GetTokenSymbol(ctx context.Context, client *Client, tokenContract string) (string, error) {
+	result, err := client.Eth().Call(ctx, NewCallObject(tokenContract).SetData(FuncSymbol.Selector()), BlockLatest)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := hex.DecodeString(StripHexPrefix(result))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode symbol: %w", err)
+	}
+	if len(data) == 32 {
+		return strings.TrimRight(string(data), "\x00"), nil
+	}
+
+	values, err := DecodeFunctionResult([]string{"string"}, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode symbol: %w", err)
+	}
+	return values[0].(string), nil
+}
+
 // Address helpers
 func IsZeroAddress(address string) bool {
 	return address == ZeroAddress.String() || address == "0x0"
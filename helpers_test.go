@@ -0,0 +1,61 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseAmountHelpers(t *testing.T) {
+	parsers := map[string]func(string) (*big.Int, error){
+		"ParseEther": ParseEther,
+		"ParseUnits": func(s string) (*big.Int, error) { return ParseUnits(s, 6) },
+		"EtherToWei": EtherToWei,
+		"GweiToWei":  GweiToWei,
+	}
+	tests := []struct {
+		parser string
+		value  string
+		want   string // empty means an error is expected
+	}{
+		{"ParseEther", "2.5", "2500000000000000000"},
+		{"ParseEther", "1.000000000000000001", "1000000000000000001"},
+		{"ParseEther", "0.0000000000000000001", ""},
+		{"ParseUnits", "100.5", "100500000"},
+		{"ParseUnits", "0.000001", "1"},
+		{"ParseUnits", "0.0000001", ""},
+		{"EtherToWei", "2", "2000000000000000000"},
+		{"EtherToWei", "1.000000000000000001", "1000000000000000001"},
+		{"GweiToWei", "20", "20000000000"},
+		{"GweiToWei", "1.000000001", "1000000001"},
+		{"GweiToWei", "1.0000000001", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := parsers[tt.parser](tt.value)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s(%q) = %s, want error", tt.parser, tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s(%q) error: %v", tt.parser, tt.value, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s(%q) = %s, want %s", tt.parser, tt.value, got, tt.want)
+		}
+	}
+
+	for name, parse := range parsers {
+		for _, value := range []string{"", "abc", "1e3", "Inf", "1.2.3", "0x1", "-"} {
+			if got, err := parse(value); err == nil {
+				t.Errorf("%s(%q) = %s, want error", name, value, got)
+			}
+		}
+	}
+
+	if _, err := ParseUnits("1", -1); err == nil {
+		t.Error("ParseUnits with negative decimals succeeded, want error")
+	}
+}
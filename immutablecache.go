@@ -0,0 +1,140 @@
+package web3
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+)
+
+// immutableCache is a bounded LRU of RPC results that can never change:
+// blocks by hash, and blocks and code at or below the finalized head.
+type immutableCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // front is most recently used
+	entries    map[string]*list.Element
+	finalized  uint64 // highest finalized block number seen, 0 if none
+}
+
+type immutableCacheEntry struct {
+	key    string
+	result json.RawMessage
+}
+
+func newImmutableCache(maxEntries int) *immutableCache {
+	return &immutableCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func immutableCacheKey(method string, params []interface{}) (string, bool) {
+	switch method {
+	case EthGetCode.String(), EthGetBlockByHash.String(), EthGetBlockByNumber.String():
+	default:
+		return "", false
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	return method + string(encoded), true
+}
+
+func (ic *immutableCache) get(method string, params []interface{}) (json.RawMessage, bool) {
+	key, ok := immutableCacheKey(method, params)
+	if !ok {
+		return nil, false
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	elem, ok := ic.entries[key]
+	if !ok {
+		return nil, false
+	}
+	ic.order.MoveToFront(elem)
+	return elem.Value.(*immutableCacheEntry).result, true
+}
+
+// store caches result if it is immutable. Missing blocks are never cached
+// since they may appear later; blocks and code by number are only cached
+// once they are finalized, as learned from "finalized" block queries.
+func (ic *immutableCache) store(method string, params []interface{}, result json.RawMessage) {
+	key, ok := immutableCacheKey(method, params)
+	if !ok || isNullResult(result) {
+		return
+	}
+
+	switch method {
+	case EthGetCode.String():
+		if len(params) < 2 || !ic.isImmutableState(params[1]) {
+			return
+		}
+	case EthGetBlockByNumber.String():
+		if len(params) == 0 {
+			return
+		}
+		tag, _ := params[0].(string)
+		if tag == BlockFinalized.String() {
+			ic.observeFinalized(result)
+			return
+		}
+		number, err := hexToUint64(tag)
+		if err != nil || !ic.isFinalized(number) {
+			return
+		}
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if elem, ok := ic.entries[key]; ok {
+		ic.order.MoveToFront(elem)
+		return
+	}
+	ic.entries[key] = ic.order.PushFront(&immutableCacheEntry{key: key, result: result})
+	for ic.order.Len() > ic.maxEntries {
+		oldest := ic.order.Back()
+		ic.order.Remove(oldest)
+		delete(ic.entries, oldest.Value.(*immutableCacheEntry).key)
+	}
+}
+
+func (ic *immutableCache) observeFinalized(result json.RawMessage) {
+	var block struct {
+		Number string `json:"number"`
+	}
+	if json.Unmarshal(result, &block) != nil {
+		return
+	}
+	number, err := hexToUint64(block.Number)
+	if err != nil {
+		return
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	ic.finalized = max(ic.finalized, number)
+}
+
+// isImmutableState reports whether a block parameter pins state that can no
+// longer change: a finalized block number or an EIP-1898 block hash.
+func (ic *immutableCache) isImmutableState(block interface{}) bool {
+	switch b := block.(type) {
+	case string:
+		number, err := hexToUint64(b)
+		return err == nil && ic.isFinalized(number)
+	case map[string]interface{}:
+		_, ok := b["blockHash"]
+		return ok
+	default:
+		return false
+	}
+}
+
+func (ic *immutableCache) isFinalized(number uint64) bool {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return ic.finalized > 0 && number <= ic.finalized
+}
@@ -0,0 +1,101 @@
+package web3
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// ExportKeystore encrypts the wallet's private key as a v3 keystore JSON
+// document, compatible with geth and other Ethereum wallets.
+func (w *Wallet) ExportKeystore(passphrase string) ([]byte, error) {
+	if w.privateKey == nil {
+		return nil, fmt.Errorf("wallet %s has no private key to export", w.address)
+	}
+
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(w.privateKey.PublicKey),
+		PrivateKey: w.privateKey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key: %w", err)
+	}
+	return keyJSON, nil
+}
+
+// NewWalletFromKeystore decrypts a v3 keystore JSON document into a wallet.
+func NewWalletFromKeystore(keyJSON []byte, passphrase string, client *Client) (*Wallet, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	return &Wallet{
+		privateKey: key.PrivateKey,
+		address:    key.Address.Hex(),
+		client:     client,
+		chainID:    ChainMainnet,
+	}, nil
+}
+
+// ExportWalletsToDirectory writes every wallet as a v3 keystore file into dir,
+// using geth's "UTC--<timestamp>--<address>" file naming.
+func ExportWalletsToDirectory(wallets []*Wallet, dir, passphrase string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	for _, w := range wallets {
+		keyJSON, err := w.ExportKeystore(passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", w.address, err)
+		}
+
+		path := filepath.Join(dir, keystoreFileName(w.address, time.Now().UTC()))
+		if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+			return fmt.Errorf("failed to write keystore file: %w", err)
+		}
+	}
+	return nil
+}
+
+// ImportWalletsFromDirectory decrypts every keystore file in dir. Hidden files
+// and subdirectories are skipped.
+func ImportWalletsFromDirectory(dir, passphrase string, client *Client) ([]*Wallet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore directory: %w", err)
+	}
+
+	var wallets []*Wallet
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		keyJSON, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		wallet, err := NewWalletFromKeystore(keyJSON, passphrase, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import %s: %w", entry.Name(), err)
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, nil
+}
+
+func keystoreFileName(address string, t time.Time) string {
+	timestamp := t.Format("2006-01-02T15-04-05.000000000Z")
+	return fmt.Sprintf("UTC--%s--%s", timestamp, strings.ToLower(StripHexPrefix(address)))
+}
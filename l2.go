@@ -0,0 +1,69 @@
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// OPStackGasPriceOracle is the GasPriceOracle predeploy on OP-stack chains.
+const OPStackGasPriceOracle = "0x420000000000000000000000000000000000000F"
+
+// opStackChains lists the known OP-stack chains, whose fees include an L1
+// data fee charged on top of L2 execution.
+var opStackChains = map[ChainID]bool{
+	ChainOptimism:       true,
+	ChainOptimismGoerli: true,
+	ChainID(8453):       true, // Base
+	ChainID(84531):      true, // Base Goerli
+	ChainID(84532):      true, // Base Sepolia
+}
+
+// EstimateL2Fee estimates the total fee of a signed transaction: its gas
+// limit times its max fee per gas (or gas price), plus on OP-stack chains the
+// L1 data fee reported by the GasPriceOracle predeploy. On other chains only
+// the execution fee is returned.
+func EstimateL2Fee(ctx context.Context, client *Client, chainID ChainID, signedRawTx string) (*big.Int, error) {
+	rawTx, err := hex.DecodeString(StripHexPrefix(signedRawTx))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string: %w", err)
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+
+	if !opStackChains[chainID] {
+		return fee, nil
+	}
+
+	l1Fee, err := getL1Fee(ctx, client, rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 fee: %w", err)
+	}
+	return fee.Add(fee, l1Fee), nil
+}
+
+func getL1Fee(ctx context.Context, client *Client, rawTx []byte) (*big.Int, error) {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	args, err := abi.Arguments{{Type: bytesType}}.Pack(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getL1Fee call: %w", err)
+	}
+
+	data := append(FunctionSignature("getL1Fee(bytes)").Selector(), args...)
+	result, err := client.Eth().Call(ctx, NewCallObject(OPStackGasPriceOracle).SetData(data), BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+	return FromHex(result)
+}
@@ -0,0 +1,132 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+)
+
+// Log is an event log emitted by a contract.
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+// LogFilter selects logs for GetLogs. Topics holds the accepted values per
+// topic position; an empty position matches any topic.
+type LogFilter struct {
+	FromBlock BlockParameter
+	ToBlock   BlockParameter
+	Addresses []string
+	Topics    [][]string
+}
+
+func (f LogFilter) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if f.FromBlock != "" {
+		m["fromBlock"] = f.FromBlock.String()
+	}
+	if f.ToBlock != "" {
+		m["toBlock"] = f.ToBlock.String()
+	}
+	if len(f.Addresses) > 0 {
+		m["address"] = f.Addresses
+	}
+	if len(f.Topics) > 0 {
+		topics := make([]interface{}, len(f.Topics))
+		for i, position := range f.Topics {
+			switch len(position) {
+			case 0:
+				topics[i] = nil
+			case 1:
+				topics[i] = position[0]
+			default:
+				topics[i] = position
+			}
+		}
+		m["topics"] = topics
+	}
+	return m
+}
+
+// GetLogs returns the logs matching filter.
+func (e *Eth) GetLogs(ctx context.Context, filter LogFilter) ([]Log, error) {
+	logs, err := CallInto[[]Log](ctx, e.client, EthGetLogs.String(), []interface{}{filter.toMap()})
+	if err != nil {
+		return nil, err
+	}
+	for i := range logs {
+		logs[i].Address = e.client.formatAddress(logs[i].Address)
+	}
+	return logs, nil
+}
+
+const defaultLogPageSize = 2000
+
+// GetLogsPaged is like GetLogs but queries the block range in windows of
+// pageSize blocks (2000 if zero), staying under provider limits on the
+// block range or result count of a single eth_getLogs call.
+func (e *Eth) GetLogsPaged(ctx context.Context, filter LogFilter, pageSize uint64) ([]Log, error) {
+	if pageSize == 0 {
+		pageSize = defaultLogPageSize
+	}
+
+	from, err := e.resolveBlockNumber(ctx, filter.FromBlock, BlockEarliest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fromBlock: %w", err)
+	}
+	to, err := e.resolveBlockNumber(ctx, filter.ToBlock, BlockLatest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid toBlock: %w", err)
+	}
+
+	var logs []Log
+	for start := from; start <= to; start += pageSize {
+		end := min(start+pageSize-1, to)
+
+		page := filter
+		page.FromBlock = BlockNumber(start)
+		page.ToBlock = BlockNumber(end)
+		pageLogs, err := e.GetLogs(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs for blocks %d-%d: %w", start, end, err)
+		}
+		logs = append(logs, pageLogs...)
+
+		if end == to {
+			break
+		}
+	}
+	return logs, nil
+}
+
+// resolveBlockNumber turns a block parameter into a concrete block number,
+// using def when the parameter is empty.
+func (e *Eth) resolveBlockNumber(ctx context.Context, block BlockParameter, def BlockParameter) (uint64, error) {
+	if block == "" {
+		block = def
+	}
+	switch block {
+	case BlockEarliest:
+		return 0, nil
+	case BlockLatest, BlockPending:
+		return e.GetBlockNumber(ctx)
+	case BlockSafe, BlockFinalized:
+		header, err := e.GetBlockByNumber(ctx, block, false)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get %s block: %w", block, err)
+		}
+		if header.Number == "" {
+			return 0, fmt.Errorf("no %s block", block)
+		}
+		return hexToUint64(header.Number)
+	default:
+		return hexToUint64(block.String())
+	}
+}
@@ -0,0 +1,310 @@
+package web3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// A minimal in-memory Merkle-Patricia trie, enough to rebuild the small
+// per-block tries (receipts, transactions) and to produce and check
+// inclusion proofs without go-ethereum's trie database. Keys are stored as
+// nibbles.
+
+type mptNode interface{}
+
+type mptLeaf struct {
+	key   []byte
+	value []byte
+}
+
+type mptExtension struct {
+	key   []byte
+	child mptNode
+}
+
+type mptBranch struct {
+	children [16]mptNode
+	value    []byte
+}
+
+var errMPTKeyNotFound = errors.New("key not found in trie")
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+func commonPrefixLen(a, b []byte) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// mptInsert returns n with value stored under the nibble path key.
+func mptInsert(n mptNode, key, value []byte) mptNode {
+	switch n := n.(type) {
+	case nil:
+		return &mptLeaf{key: key, value: value}
+	case *mptBranch:
+		if len(key) == 0 {
+			n.value = value
+			return n
+		}
+		n.children[key[0]] = mptInsert(n.children[key[0]], key[1:], value)
+		return n
+	case *mptLeaf:
+		shared := commonPrefixLen(n.key, key)
+		if shared == len(n.key) && shared == len(key) {
+			n.value = value
+			return n
+		}
+		branch := &mptBranch{}
+		branch.insertBelow(n.key[shared:], n.value)
+		branch.insertBelow(key[shared:], value)
+		return wrapExtension(key[:shared], branch)
+	case *mptExtension:
+		shared := commonPrefixLen(n.key, key)
+		if shared == len(n.key) {
+			n.child = mptInsert(n.child, key[shared:], value)
+			return n
+		}
+		branch := &mptBranch{}
+		rest := n.key[shared:]
+		branch.children[rest[0]] = wrapExtension(rest[1:], n.child)
+		branch.insertBelow(key[shared:], value)
+		return wrapExtension(key[:shared], branch)
+	default:
+		panic(fmt.Sprintf("unexpected trie node %T", n))
+	}
+}
+
+func (b *mptBranch) insertBelow(key, value []byte) {
+	if len(key) == 0 {
+		b.value = value
+		return
+	}
+	b.children[key[0]] = mptInsert(b.children[key[0]], key[1:], value)
+}
+
+func wrapExtension(key []byte, child mptNode) mptNode {
+	if len(key) == 0 {
+		return child
+	}
+	return &mptExtension{key: key, child: child}
+}
+
+// hexPrefix applies the compact (hex-prefix) encoding to a nibble path.
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	out := []byte{flag << 4}
+	if len(nibbles)%2 == 1 {
+		out[0] |= 1<<4 | nibbles[0]
+		nibbles = nibbles[1:]
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		out = append(out, nibbles[i]<<4|nibbles[i+1])
+	}
+	return out
+}
+
+// decodeHexPrefix reverses hexPrefix.
+func decodeHexPrefix(compact []byte) (nibbles []byte, leaf bool, err error) {
+	if len(compact) == 0 {
+		return nil, false, fmt.Errorf("empty hex-prefix key")
+	}
+	flag := compact[0] >> 4
+	if flag > 3 {
+		return nil, false, fmt.Errorf("invalid hex-prefix flag %d", flag)
+	}
+	if flag&1 == 1 {
+		nibbles = append(nibbles, compact[0]&0x0f)
+	}
+	nibbles = append(nibbles, keyToNibbles(compact[1:])...)
+	return nibbles, flag&2 == 2, nil
+}
+
+// mptEncode returns the RLP encoding of a node.
+func mptEncode(n mptNode) []byte {
+	var items []interface{}
+	switch n := n.(type) {
+	case nil:
+		return []byte{0x80}
+	case *mptLeaf:
+		items = []interface{}{hexPrefix(n.key, true), n.value}
+	case *mptExtension:
+		items = []interface{}{hexPrefix(n.key, false), mptReference(n.child)}
+	case *mptBranch:
+		items = make([]interface{}, 17)
+		for i, child := range n.children {
+			items[i] = mptReference(child)
+		}
+		items[16] = n.value
+		if n.value == nil {
+			items[16] = []byte{}
+		}
+	}
+	enc, err := rlp.EncodeToBytes(items)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode trie node: %v", err))
+	}
+	return enc
+}
+
+// mptReference is how a parent refers to a child: nodes shorter than 32
+// bytes are embedded, longer ones referenced by hash.
+func mptReference(n mptNode) rlp.RawValue {
+	if n == nil {
+		return rlp.RawValue{0x80}
+	}
+	enc := mptEncode(n)
+	if len(enc) < 32 {
+		return enc
+	}
+	return append(rlp.RawValue{0xa0}, crypto.Keccak256(enc)...)
+}
+
+// mptRootHash returns the root hash of the trie.
+func mptRootHash(root mptNode) []byte {
+	return crypto.Keccak256(mptEncode(root))
+}
+
+// mptProve returns the encoded nodes on the path to key, root first. Nodes
+// embedded in their parent are not listed separately.
+func mptProve(root mptNode, key []byte) [][]byte {
+	var proof [][]byte
+	n := root
+	for n != nil {
+		enc := mptEncode(n)
+		if len(proof) == 0 || len(enc) >= 32 {
+			proof = append(proof, enc)
+		}
+		switch node := n.(type) {
+		case *mptLeaf:
+			return proof
+		case *mptExtension:
+			if !bytes.HasPrefix(key, node.key) {
+				return proof
+			}
+			key = key[len(node.key):]
+			n = node.child
+		case *mptBranch:
+			if len(key) == 0 {
+				return proof
+			}
+			n = node.children[key[0]]
+			key = key[1:]
+		}
+	}
+	return proof
+}
+
+// mptVerifyProof walks proof from rootHash along the nibble path key and
+// returns the stored value.
+func mptVerifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[string][]byte, len(proof))
+	for _, node := range proof {
+		nodes[string(crypto.Keccak256(node))] = node
+	}
+
+	node, ok := nodes[string(rootHash)]
+	if !ok {
+		return nil, fmt.Errorf("proof does not contain the root node")
+	}
+	for {
+		items, err := rlpListItems(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof node: %w", err)
+		}
+
+		var child []byte
+		switch len(items) {
+		case 17:
+			if len(key) == 0 {
+				value, err := rlpStringContent(items[16])
+				if err == nil && len(value) == 0 {
+					return nil, errMPTKeyNotFound
+				}
+				return value, err
+			}
+			child = items[key[0]]
+			key = key[1:]
+		case 2:
+			compact, err := rlpStringContent(items[0])
+			if err != nil {
+				return nil, err
+			}
+			path, leaf, err := decodeHexPrefix(compact)
+			if err != nil {
+				return nil, err
+			}
+			if leaf {
+				if !bytes.Equal(path, key) {
+					return nil, errMPTKeyNotFound
+				}
+				return rlpStringContent(items[1])
+			}
+			if !bytes.HasPrefix(key, path) {
+				return nil, errMPTKeyNotFound
+			}
+			key = key[len(path):]
+			child = items[1]
+		default:
+			return nil, fmt.Errorf("invalid proof node with %d items", len(items))
+		}
+
+		kind, content, _, err := rlp.Split(child)
+		if err != nil {
+			return nil, fmt.Errorf("invalid child reference: %w", err)
+		}
+		switch {
+		case kind == rlp.List:
+			node = child
+		case len(content) == 0:
+			return nil, errMPTKeyNotFound
+		case len(content) == 32:
+			if node, ok = nodes[string(content)]; !ok {
+				return nil, fmt.Errorf("proof is missing node %x", content)
+			}
+		default:
+			return nil, fmt.Errorf("invalid child reference of %d bytes", len(content))
+		}
+	}
+}
+
+// rlpListItems splits an RLP list into its raw items.
+func rlpListItems(list []byte) ([][]byte, error) {
+	content, _, err := rlp.SplitList(list)
+	if err != nil {
+		return nil, err
+	}
+	var items [][]byte
+	for len(content) > 0 {
+		_, _, rest, err := rlp.Split(content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, content[:len(content)-len(rest)])
+		content = rest
+	}
+	return items, nil
+}
+
+func rlpStringContent(item []byte) ([]byte, error) {
+	content, _, err := rlp.SplitString(item)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof value: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,155 @@
+package web3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mptTestValue is the value stored under index i. Short values keep nodes
+// under 32 bytes so they are embedded in their parent; long values force
+// every node to be referenced by hash.
+func mptTestValue(i int, long bool) []byte {
+	if long {
+		return append(crypto.Keccak256([]byte(fmt.Sprintf("value %d", i))), "-receipt"...)
+	}
+	return []byte(fmt.Sprintf("v%d", i))
+}
+
+func buildTestMPT(n int, long bool) mptNode {
+	var root mptNode
+	for i := 0; i < n; i++ {
+		root = mptInsert(root, keyToNibbles(receiptTrieKey(uint64(i))), mptTestValue(i, long))
+	}
+	return root
+}
+
+// The expected roots were computed with go-ethereum v1.13.5 as
+// types.DeriveSha(list, trie.NewStackTrie(nil)) over the same values.
+var mptReferenceRoots = []struct {
+	n    int
+	long bool
+	root string
+}{
+	{1, false, "805961674d96860f5912ea87cd65c9dcd4e407adbdaf9e3053c6109a43c89725"},
+	{2, false, "3401e6285e2ba46400dbb1665df775028d264cd481ccad4352835d7c035beb70"},
+	{16, false, "d492216b52dca049c0db862f3ef2a2b9b73c6e29e23f4ad68491282286a05e4c"},
+	{17, false, "293a3e5b8556fbe9f2227541f5c83befe3b46cbd256bcddf1e16bd01ab0aa729"},
+	{128, false, "b39b4c1efc4450bbc21c35ae3bcb201f5fb45d01e6452abbee63f01bb2cc0dc6"},
+	{300, false, "afbbe13f03c7318ae5cd6548f2efa34901e8fddbafaf61ac04b264370186cd6a"},
+	{1, true, "7a7c7f4abb7cdfbf56458b5bf59e6f99a5b6ef44d6106c7a5572ea3bf44fbf93"},
+	{2, true, "ca0ad21e9a45d00322a01c8d0581484fc2bba08090b0335ca9f1956320e7eca0"},
+	{16, true, "b6f5efebd496a42f168ab32ea1ee9ae555a3db0fd646b4d7b2e84396259c660e"},
+	{17, true, "c0c3fb73354cbadded14075327b327ae1e480e67b19c62bc81c0373abf81753b"},
+	{128, true, "e4ed2decca485fec0847b96090ef1387b8cc09869dc78d0b40254556949e6cba"},
+	{300, true, "d26b58d1adca71b04b6dd36eca2f7a5c676bdc6b6c5e63b7c27e52ca65194d24"},
+}
+
+func TestMPTRootHash(t *testing.T) {
+	if got := hex.EncodeToString(mptRootHash(nil)); got != "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421" {
+		t.Errorf("empty trie root = %s", got)
+	}
+	for _, tt := range mptReferenceRoots {
+		if got := hex.EncodeToString(mptRootHash(buildTestMPT(tt.n, tt.long))); got != tt.root {
+			t.Errorf("root of %d keys (long values %v) = %s, want %s", tt.n, tt.long, got, tt.root)
+		}
+	}
+}
+
+func TestMPTProofRoundTrip(t *testing.T) {
+	for _, tt := range mptReferenceRoots {
+		root := buildTestMPT(tt.n, tt.long)
+		rootHash := mptRootHash(root)
+		for i := 0; i < tt.n; i++ {
+			key := keyToNibbles(receiptTrieKey(uint64(i)))
+			value, err := mptVerifyProof(rootHash, key, mptProve(root, key))
+			if err != nil {
+				t.Errorf("%d keys (long %v): proof of key %d: %v", tt.n, tt.long, i, err)
+				continue
+			}
+			if want := mptTestValue(i, tt.long); !bytes.Equal(value, want) {
+				t.Errorf("%d keys (long %v): key %d proved %q, want %q", tt.n, tt.long, i, value, want)
+			}
+		}
+	}
+}
+
+func TestMPTProofRejectsTampering(t *testing.T) {
+	for _, tt := range mptReferenceRoots {
+		root := buildTestMPT(tt.n, tt.long)
+		rootHash := mptRootHash(root)
+		index := tt.n / 2
+		key := keyToNibbles(receiptTrieKey(uint64(index)))
+		proof := mptProve(root, key)
+
+		for i := range proof {
+			for _, pos := range []int{len(proof[i]) / 2, len(proof[i]) - 1} {
+				tampered := make([][]byte, len(proof))
+				copy(tampered, proof)
+				tampered[i] = append([]byte(nil), proof[i]...)
+				tampered[i][pos] ^= 0x01
+				if value, err := mptVerifyProof(rootHash, key, tampered); err == nil {
+					t.Errorf("%d keys (long %v): node %d tampered at byte %d proved %q", tt.n, tt.long, i, pos, value)
+				}
+			}
+		}
+
+		missing := keyToNibbles(receiptTrieKey(uint64(tt.n)))
+		if value, err := mptVerifyProof(rootHash, missing, mptProve(root, missing)); err == nil {
+			t.Errorf("%d keys (long %v): absent key %d proved %q", tt.n, tt.long, tt.n, value)
+		}
+		if tt.n > 1 {
+			// A proof for one key can legitimately contain a sibling leaf
+			// embedded in a branch, but must never prove a wrong value.
+			otherIndex := (index + 1) % tt.n
+			other := keyToNibbles(receiptTrieKey(uint64(otherIndex)))
+			if value, err := mptVerifyProof(rootHash, other, proof); err == nil && !bytes.Equal(value, mptTestValue(otherIndex, tt.long)) {
+				t.Errorf("%d keys (long %v): proof of key %d proved %q for key %d", tt.n, tt.long, index, value, otherIndex)
+			}
+		}
+
+		wrongRoot := crypto.Keccak256(rootHash)
+		if _, err := mptVerifyProof(wrongRoot, key, proof); err == nil {
+			t.Errorf("%d keys (long %v): proof accepted under a wrong root", tt.n, tt.long)
+		}
+	}
+}
+
+func TestMPTProofBranchWithoutValue(t *testing.T) {
+	var root mptNode
+	root = mptInsert(root, []byte{1, 2}, []byte("a"))
+	root = mptInsert(root, []byte{1, 3}, []byte("b"))
+	if value, err := mptVerifyProof(mptRootHash(root), []byte{1}, mptProve(root, []byte{1})); err == nil {
+		t.Errorf("key without a value proved %q", value)
+	}
+}
+
+func TestReceiptProofVerify(t *testing.T) {
+	root := buildTestMPT(300, true)
+	key := receiptTrieKey(200)
+	proof := &ReceiptProof{
+		Index:   200,
+		Key:     "0x" + hex.EncodeToString(key),
+		Receipt: "0x" + hex.EncodeToString(mptTestValue(200, true)),
+	}
+	for _, node := range mptProve(root, keyToNibbles(key)) {
+		proof.Proof = append(proof.Proof, "0x"+hex.EncodeToString(node))
+	}
+	receiptsRoot := "0x" + hex.EncodeToString(mptRootHash(root))
+
+	if err := proof.Verify(receiptsRoot); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := proof.Verify("0x" + hex.EncodeToString(crypto.Keccak256([]byte("other")))); err == nil {
+		t.Error("Verify accepted a wrong receipts root")
+	}
+
+	forged := *proof
+	forged.Receipt = "0x" + hex.EncodeToString(mptTestValue(201, true))
+	if err := forged.Verify(receiptsRoot); err == nil {
+		t.Error("Verify accepted a different receipt")
+	}
+}
@@ -0,0 +1,133 @@
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultMulticallAddress is the canonical Multicall3 deployment address,
+// identical on most EVM chains.
+const DefaultMulticallAddress = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// multicallAddresses lists known Multicall3 deployments per chain. Chains not
+// listed fall back to DefaultMulticallAddress.
+var multicallAddresses = map[ChainID]string{
+	ChainMainnet:        DefaultMulticallAddress,
+	ChainGoerli:         DefaultMulticallAddress,
+	ChainSepolia:        DefaultMulticallAddress,
+	ChainOptimism:       DefaultMulticallAddress,
+	ChainOptimismGoerli: DefaultMulticallAddress,
+	ChainArbitrum:       DefaultMulticallAddress,
+	ChainArbitrumGoerli: DefaultMulticallAddress,
+	ChainPolygon:        DefaultMulticallAddress,
+	ChainPolygonMumbai:  DefaultMulticallAddress,
+	ChainAvalanche:      DefaultMulticallAddress,
+	ChainAvalancheFuji:  DefaultMulticallAddress,
+	ChainBSC:            DefaultMulticallAddress,
+	ChainBSCTestnet:     DefaultMulticallAddress,
+	ChainFantom:         DefaultMulticallAddress,
+	ChainFantomTestnet:  DefaultMulticallAddress,
+	ChainID(324):        "0xF9cda624FBC7e059355ce98a31693d299FACd963", // zkSync Era
+}
+
+// MulticallAddress returns the Multicall3 address known for chainID, or
+// DefaultMulticallAddress if the chain is not in the registry.
+func MulticallAddress(chainID ChainID) string {
+	if address, ok := multicallAddresses[chainID]; ok {
+		return address
+	}
+	return DefaultMulticallAddress
+}
+
+// RegisterMulticallAddress records the Multicall3 deployment of a chain, for
+// chains where it lives at a non-standard address. It is meant to be called
+// during initialization and is not safe for concurrent use with Multicall
+// lookups.
+func RegisterMulticallAddress(chainID ChainID, address string) error {
+	if !IsAddress(address) {
+		return fmt.Errorf("invalid multicall address: %s", address)
+	}
+	multicallAddresses[chainID] = address
+	return nil
+}
+
+const multicall3ABI = `[{"name":"aggregate3","type":"function","stateMutability":"payable",
+"inputs":[{"name":"calls","type":"tuple[]","components":[
+{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],
+"outputs":[{"name":"returnData","type":"tuple[]","components":[
+{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+// MulticallCall is a single call aggregated by Multicall.
+type MulticallCall struct {
+	Target       string
+	AllowFailure bool
+	CallData     []byte
+}
+
+// MulticallResult is the outcome of one MulticallCall.
+type MulticallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Multicall executes calls in a single eth_call through the Multicall3
+// aggregate3 function of the contract at multicallAddress. Use
+// MulticallAddress to look up the deployment for a chain. If a call that
+// does not allow failure reverts, the whole eth_call fails.
+func Multicall(ctx context.Context, client *Client, multicallAddress string, calls []MulticallCall) ([]MulticallResult, error) {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multicall ABI: %w", err)
+	}
+
+	type call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+	args := make([]call3, len(calls))
+	for i, call := range calls {
+		if !IsAddress(call.Target) {
+			return nil, fmt.Errorf("invalid target address for call %d: %s", i, call.Target)
+		}
+		args[i] = call3{
+			Target:       common.HexToAddress(call.Target),
+			AllowFailure: call.AllowFailure,
+			CallData:     call.CallData,
+		}
+	}
+
+	data, err := parsed.Pack("aggregate3", args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode multicall: %w", err)
+	}
+
+	result, err := client.Eth().Call(ctx, NewCallObject(multicallAddress).SetData(data), BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(StripHexPrefix(result))
+	if err != nil {
+		return nil, fmt.Errorf("invalid multicall result: %w", err)
+	}
+
+	var decoded []struct {
+		Success    bool
+		ReturnData []byte
+	}
+	if err := parsed.UnpackIntoInterface(&decoded, "aggregate3", raw); err != nil {
+		return nil, fmt.Errorf("failed to decode multicall result: %w", err)
+	}
+
+	results := make([]MulticallResult, len(decoded))
+	for i, r := range decoded {
+		results[i] = MulticallResult{Success: r.Success, ReturnData: r.ReturnData}
+	}
+	return results, nil
+}
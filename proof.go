@@ -0,0 +1,179 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReceiptProof is a Merkle-Patricia proof that a receipt is part of a
+// block's receipts trie. Key is the trie key (the RLP-encoded transaction
+// index), Receipt the consensus encoding of the receipt stored under it and
+// Proof the trie nodes from the root down, all hex-encoded.
+type ReceiptProof struct {
+	TxHash       string
+	BlockHash    string
+	BlockNumber  uint64
+	ReceiptsRoot string
+	Index        uint64
+	Key          string
+	Receipt      string
+	Proof        []string
+}
+
+// GetReceiptProof builds the inclusion proof of a transaction's receipt by
+// fetching every receipt of its block and rebuilding the receipts trie. The
+// rebuilt root is checked against the block's receiptsRoot, so chains with
+// non-standard receipt encodings are reported as an error.
+func GetReceiptProof(ctx context.Context, client *Client, txHash string) (*ReceiptProof, error) {
+	eth := client.Eth()
+
+	receipt, err := eth.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("no receipt for transaction %s", txHash)
+	}
+	index, err := hexToUint64(receipt.TransactionIndex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction index: %w", err)
+	}
+
+	block, err := eth.GetBlockByHash(ctx, receipt.BlockHash, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	hashes, err := block.TransactionHashes()
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := eth.getReceipts(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	var root mptNode
+	for i, r := range receipts {
+		encoded, err := consensusReceipt(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode receipt %s: %w", hashes[i], err)
+		}
+		root = mptInsert(root, keyToNibbles(receiptTrieKey(uint64(i))), encoded)
+	}
+	if rootHash := mptRootHash(root); !bytes.Equal(rootHash, common.FromHex(block.ReceiptsRoot)) {
+		return nil, fmt.Errorf("rebuilt receipts root 0x%x does not match block receiptsRoot %s", rootHash, block.ReceiptsRoot)
+	}
+
+	key := receiptTrieKey(index)
+	value, err := consensusReceipt(receipts[index])
+	if err != nil {
+		return nil, err
+	}
+	proof := &ReceiptProof{
+		TxHash:       receipt.TransactionHash,
+		BlockHash:    receipt.BlockHash,
+		ReceiptsRoot: block.ReceiptsRoot,
+		Index:        index,
+		Key:          fmt.Sprintf("0x%x", key),
+		Receipt:      fmt.Sprintf("0x%x", value),
+	}
+	if proof.BlockNumber, err = hexToUint64(receipt.BlockNumber); err != nil {
+		return nil, fmt.Errorf("invalid block number: %w", err)
+	}
+	for _, node := range mptProve(root, keyToNibbles(key)) {
+		proof.Proof = append(proof.Proof, fmt.Sprintf("0x%x", node))
+	}
+	return proof, nil
+}
+
+// Verify checks the proof against receiptsRoot, which should come from a
+// trusted block header rather than from the proof itself.
+func (p *ReceiptProof) Verify(receiptsRoot string) error {
+	nodes := make([][]byte, len(p.Proof))
+	for i, node := range p.Proof {
+		nodes[i] = common.FromHex(node)
+	}
+
+	value, err := mptVerifyProof(common.FromHex(receiptsRoot), keyToNibbles(common.FromHex(p.Key)), nodes)
+	if err != nil {
+		return fmt.Errorf("invalid receipt proof: %w", err)
+	}
+	if !bytes.Equal(value, common.FromHex(p.Receipt)) {
+		return fmt.Errorf("invalid receipt proof: proven value does not match receipt")
+	}
+	return nil
+}
+
+// getReceipts fetches receipts for hashes in a single batch request.
+func (e *Eth) getReceipts(ctx context.Context, hashes []string) ([]*TransactionReceipt, error) {
+	batch := make([]BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = BatchElem{
+			Method: EthGetTransactionReceipt.String(),
+			Params: []interface{}{hash},
+		}
+	}
+	if err := e.client.BatchCall(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	receipts := make([]*TransactionReceipt, len(hashes))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hashes[i], elem.Error)
+		}
+		receipt, err := e.decodeReceipt(elem.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hashes[i], err)
+		}
+		if receipt == nil {
+			return nil, fmt.Errorf("receipt %s not found", hashes[i])
+		}
+		receipts[i] = receipt
+	}
+	return receipts, nil
+}
+
+func receiptTrieKey(index uint64) []byte {
+	key, _ := rlp.EncodeToBytes(index)
+	return key
+}
+
+// consensusReceipt returns the encoding of an RPC receipt as stored in the
+// receipts trie.
+func consensusReceipt(r *TransactionReceipt) ([]byte, error) {
+	cumulativeGasUsed, err := hexToUint64(r.CumulativeGasUsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cumulativeGasUsed: %w", err)
+	}
+
+	receipt := &types.Receipt{
+		Type:              uint8(r.Type),
+		CumulativeGasUsed: cumulativeGasUsed,
+		Bloom:             types.BytesToBloom(common.FromHex(r.LogsBloom)),
+	}
+	if r.Root != "" {
+		receipt.PostState = common.FromHex(r.Root)
+	} else if receipt.Status, err = hexToUint64(r.Status); err != nil {
+		return nil, fmt.Errorf("invalid status: %w", err)
+	}
+
+	for _, l := range r.Logs {
+		topics := make([]common.Hash, len(l.Topics))
+		for i, topic := range l.Topics {
+			topics[i] = common.HexToHash(topic)
+		}
+		receipt.Logs = append(receipt.Logs, &types.Log{
+			Address: common.HexToAddress(l.Address),
+			Topics:  topics,
+			Data:    common.FromHex(strings.TrimSpace(l.Data)),
+		})
+	}
+	return receipt.MarshalBinary()
+}
@@ -0,0 +1,147 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// Proof-of-work block rewards on Ethereum mainnet, by fork block.
+var (
+	frontierBlockReward       = new(big.Int).Mul(big.NewInt(5), big.NewInt(1e18))
+	byzantiumBlockReward      = new(big.Int).Mul(big.NewInt(3), big.NewInt(1e18))
+	constantinopleBlockReward = new(big.Int).Mul(big.NewInt(2), big.NewInt(1e18))
+)
+
+const (
+	byzantiumBlock      = 4370000
+	constantinopleBlock = 7280000
+)
+
+// ComputeBlockReward returns the execution-layer reward of the block's fee
+// recipient: the static block reward, the priority fees of its transactions
+// (gasUsed × (effectiveGasPrice − baseFee)) and 1/32 of the block reward for
+// every included uncle. Burnt base fees are not part of the reward.
+//
+// Static and uncle rewards follow the Ethereum mainnet schedule and only
+// apply to proof-of-work blocks on mainnet; blocks with zero difficulty
+// (proof of stake) and blocks of other chains, whose difficulty field means
+// something else (e.g. BSC, Polygon PoS), earn priority fees only.
+func ComputeBlockReward(ctx context.Context, client *Client, block BlockParameter) (*big.Int, error) {
+	eth := client.Eth()
+
+	header, err := eth.GetBlockByNumber(ctx, block, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	if header.Number == "" {
+		return nil, fmt.Errorf("block %s not found", block)
+	}
+
+	chainID, err := eth.GetChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	reward, err := staticBlockReward(header, chainID)
+	if err != nil {
+		return nil, err
+	}
+	uncleReward := new(big.Int).Div(reward, big.NewInt(32))
+	reward.Add(reward, uncleReward.Mul(uncleReward, big.NewInt(int64(len(header.Uncles)))))
+
+	tips, err := blockPriorityFees(ctx, eth, header)
+	if err != nil {
+		return nil, err
+	}
+	return reward.Add(reward, tips), nil
+}
+
+func staticBlockReward(block *Block, chainID ChainID) (*big.Int, error) {
+	if chainID != ChainMainnet {
+		return big.NewInt(0), nil
+	}
+	difficulty, err := FromHex(block.Difficulty)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block difficulty: %w", err)
+	}
+	if difficulty.Sign() == 0 {
+		return big.NewInt(0), nil
+	}
+
+	number, err := hexToUint64(block.Number)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %w", err)
+	}
+	switch {
+	case number >= constantinopleBlock:
+		return new(big.Int).Set(constantinopleBlockReward), nil
+	case number >= byzantiumBlock:
+		return new(big.Int).Set(byzantiumBlockReward), nil
+	default:
+		return new(big.Int).Set(frontierBlockReward), nil
+	}
+}
+
+// blockPriorityFees sums what the block's transactions paid above the base
+// fee, fetching all receipts in one batch request.
+func blockPriorityFees(ctx context.Context, eth *Eth, block *Block) (*big.Int, error) {
+	baseFee := big.NewInt(0)
+	if block.BaseFeePerGas != "" {
+		var err error
+		if baseFee, err = FromHex(block.BaseFeePerGas); err != nil {
+			return nil, fmt.Errorf("invalid base fee: %w", err)
+		}
+	}
+
+	hashes := make([]string, len(block.Transactions))
+	gasPrices := make([]string, len(block.Transactions))
+	batch := make([]BatchElem, len(block.Transactions))
+	for i, raw := range block.Transactions {
+		tx, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("transaction %d is %T, not a transaction object", i, raw)
+		}
+		hashes[i], _ = tx["hash"].(string)
+		gasPrices[i], _ = tx["gasPrice"].(string)
+		batch[i] = BatchElem{
+			Method: EthGetTransactionReceipt.String(),
+			Params: []interface{}{hashes[i]},
+		}
+	}
+	if err := eth.client.BatchCall(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	total := big.NewInt(0)
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hashes[i], elem.Error)
+		}
+		receipt, err := eth.decodeReceipt(elem.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", hashes[i], err)
+		}
+		if receipt == nil {
+			return nil, fmt.Errorf("receipt %s not found", hashes[i])
+		}
+
+		// Pre-London receipts may lack effectiveGasPrice; the gas price
+		// then is the price paid.
+		priceHex := receipt.EffectiveGasPrice
+		if priceHex == "" {
+			priceHex = gasPrices[i]
+		}
+		price, err := FromHex(priceHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas price of %s: %w", hashes[i], err)
+		}
+		gasUsed, err := FromHex(receipt.GasUsed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas used of %s: %w", hashes[i], err)
+		}
+
+		tip := price.Sub(price, baseFee)
+		total.Add(total, tip.Mul(tip, gasUsed))
+	}
+	return total, nil
+}
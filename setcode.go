@@ -0,0 +1,150 @@
+package web3
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EIP-7702 constants. The go-ethereum version this package pins predates
+// types.SetCodeTx, so set-code transactions are encoded here directly.
+const (
+	SetCodeTxType      = 0x04
+	authorizationMagic = 0x05
+)
+
+// Authorization is a signed EIP-7702 authorization tuple delegating the
+// signer's account code to Address.
+type Authorization struct {
+	ChainID *big.Int `json:"chainId"`
+	Address string   `json:"address"`
+	Nonce   uint64   `json:"nonce"`
+	YParity uint8    `json:"yParity"`
+	R       *big.Int `json:"r"`
+	S       *big.Int `json:"s"`
+}
+
+type SetCodeTransactionParams struct {
+	From                 string          `json:"from"`
+	To                   string          `json:"to"`
+	Value                *big.Int        `json:"value"`
+	Gas                  uint64          `json:"gas"`
+	MaxFeePerGas         *big.Int        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int        `json:"maxPriorityFeePerGas"`
+	Data                 []byte          `json:"data"`
+	Nonce                uint64          `json:"nonce"`
+	ChainID              *big.Int        `json:"chainId"`
+	AccessList           []AccessTuple   `json:"accessList"`
+	AuthorizationList    []Authorization `json:"authorizationList"`
+}
+
+func NewSetCodeTransactionParams() *SetCodeTransactionParams {
+	return &SetCodeTransactionParams{
+		Value:   big.NewInt(0),
+		Data:    []byte{},
+		ChainID: ChainMainnet.BigInt(),
+	}
+}
+
+// SignAuthorization signs an EIP-7702 authorization for the given chain
+// (0 means valid on any chain), delegate address and authority nonce.
+func SignAuthorization(chainID *big.Int, address string, nonce uint64, privateKey *ecdsa.PrivateKey) (*Authorization, error) {
+	if !IsAddress(address) {
+		return nil, fmt.Errorf("invalid delegate address: %s", address)
+	}
+	if chainID == nil {
+		chainID = big.NewInt(0)
+	}
+
+	payload, err := rlp.EncodeToBytes([]interface{}{chainID, common.HexToAddress(address), nonce})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode authorization: %w", err)
+	}
+	digest := crypto.Keccak256(append([]byte{authorizationMagic}, payload...))
+
+	signature, err := SignDigestRaw(digest, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign authorization: %w", err)
+	}
+
+	return &Authorization{
+		ChainID: new(big.Int).Set(chainID),
+		Address: common.HexToAddress(address).Hex(),
+		Nonce:   nonce,
+		YParity: signature[64],
+		R:       new(big.Int).SetBytes(signature[:32]),
+		S:       new(big.Int).SetBytes(signature[32:64]),
+	}, nil
+}
+
+// SignSetCodeTransaction signs a type-4 EIP-7702 set-code transaction.
+func SignSetCodeTransaction(tx *SetCodeTransactionParams, privateKey *ecdsa.PrivateKey) (*SignedTransaction, error) {
+	if tx.To == "" {
+		return nil, fmt.Errorf("transaction recipient (to) is required")
+	}
+	if tx.MaxFeePerGas == nil {
+		return nil, fmt.Errorf("maxFeePerGas is required")
+	}
+	if tx.MaxPriorityFeePerGas == nil {
+		return nil, fmt.Errorf("maxPriorityFeePerGas is required")
+	}
+	if err := validateFeeCaps(tx.MaxFeePerGas, tx.MaxPriorityFeePerGas); err != nil {
+		return nil, err
+	}
+	if tx.Gas == 0 {
+		return nil, fmt.Errorf("gas limit is required")
+	}
+	if len(tx.AuthorizationList) == 0 {
+		return nil, fmt.Errorf("authorization list must not be empty")
+	}
+
+	authList := make([]interface{}, len(tx.AuthorizationList))
+	for i, auth := range tx.AuthorizationList {
+		if auth.R == nil || auth.S == nil {
+			return nil, fmt.Errorf("authorization %d is not signed", i)
+		}
+		chainID := auth.ChainID
+		if chainID == nil {
+			chainID = big.NewInt(0)
+		}
+		authList[i] = []interface{}{chainID, common.HexToAddress(auth.Address), auth.Nonce, auth.YParity, auth.R, auth.S}
+	}
+
+	fields := []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		tx.MaxPriorityFeePerGas,
+		tx.MaxFeePerGas,
+		tx.Gas,
+		common.HexToAddress(tx.To),
+		tx.Value,
+		tx.Data,
+		toGethAccessList(tx.AccessList),
+		authList,
+	}
+
+	payload, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	signature, err := SignDigestRaw(crypto.Keccak256(append([]byte{SetCodeTxType}, payload...)), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	fields = append(fields, signature[64], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:64]))
+	signedPayload, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	rawTxBytes := append([]byte{SetCodeTxType}, signedPayload...)
+
+	return &SignedTransaction{
+		Hash: crypto.Keccak256Hash(rawTxBytes).Hex(),
+		Raw:  fmt.Sprintf("0x%x", rawTxBytes),
+	}, nil
+}
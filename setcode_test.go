@@ -0,0 +1,176 @@
+package web3
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// The expected encodings below are written out byte by byte from EIP-7702
+// rather than produced with the rlp package, so they check the field order
+// and the signing payloads independently of the implementation.
+
+func TestSignAuthorization(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	delegate := "0x000000000000000000000000000000000000aaaa"
+
+	tests := []struct {
+		chainID  *big.Int
+		nonce    uint64
+		preimage string // MAGIC || rlp([chain_id, address, nonce])
+	}{
+		{big.NewInt(1), 7, "05" + "d7" + "01" + "94000000000000000000000000000000000000aaaa" + "07"},
+		{nil, 0, "05" + "d7" + "80" + "94000000000000000000000000000000000000aaaa" + "80"},
+		{big.NewInt(11155111), 1024, "05" + "dc" + "83aa36a7" + "94000000000000000000000000000000000000aaaa" + "820400"},
+	}
+	for _, tt := range tests {
+		auth, err := SignAuthorization(tt.chainID, delegate, tt.nonce, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		preimage, _ := hex.DecodeString(tt.preimage)
+		digest := crypto.Keccak256(preimage)
+
+		signature := append(common.LeftPadBytes(auth.R.Bytes(), 32), common.LeftPadBytes(auth.S.Bytes(), 32)...)
+		pub, err := crypto.SigToPub(digest, append(signature, auth.YParity))
+		if err != nil {
+			t.Errorf("chain %v: %v", tt.chainID, err)
+			continue
+		}
+		if got := crypto.PubkeyToAddress(*pub); got != signer {
+			t.Errorf("chain %v: authorization digest recovers %s, want %s", tt.chainID, got.Hex(), signer.Hex())
+		}
+		if auth.Nonce != tt.nonce || !strings.EqualFold(auth.Address, delegate) {
+			t.Errorf("chain %v: authorization = %+v", tt.chainID, auth)
+		}
+	}
+
+	if _, err := SignAuthorization(big.NewInt(1), "not an address", 0, key); err == nil {
+		t.Error("invalid delegate address accepted")
+	}
+}
+
+func TestSignSetCodeTransaction(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := SignAuthorization(big.NewInt(1), "0x000000000000000000000000000000000000aaaa", 3, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := NewSetCodeTransactionParams()
+	tx.To = "0x000000000000000000000000000000000000bbbb"
+	tx.Nonce = 2
+	tx.Gas = 100000
+	tx.MaxPriorityFeePerGas = big.NewInt(1e9)
+	tx.MaxFeePerGas = big.NewInt(2e9)
+	tx.AccessList = []AccessTuple{{
+		Address:     "0x000000000000000000000000000000000000cccc",
+		StorageKeys: []string{"0x01"},
+	}}
+	tx.AuthorizationList = []Authorization{*auth}
+
+	signed, err := SignSetCodeTransaction(tx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := common.FromHex(signed.Raw)
+	if raw[0] != SetCodeTxType {
+		t.Fatalf("transaction type = %#x, want 0x04", raw[0])
+	}
+	if signed.Hash != crypto.Keccak256Hash(raw).Hex() {
+		t.Errorf("hash %s does not match raw transaction", signed.Hash)
+	}
+
+	items, err := rlpListItems(raw[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 13 {
+		t.Fatalf("transaction has %d fields, want 13", len(items))
+	}
+
+	want := []string{
+		"01",         // chain_id
+		"02",         // nonce
+		"843b9aca00", // max_priority_fee_per_gas
+		"8477359400", // max_fee_per_gas
+		"830186a0",   // gas_limit
+		"94000000000000000000000000000000000000bbbb", // destination
+		"80", // value
+		"80", // data
+		"f838" + "f7" + "94000000000000000000000000000000000000cccc" + // access_list
+			"e1" + "a00000000000000000000000000000000000000000000000000000000000000001",
+	}
+	for i, field := range want {
+		if got := hex.EncodeToString(items[i]); got != field {
+			t.Errorf("field %d = %s, want %s", i, got, field)
+		}
+	}
+
+	var authList []struct {
+		ChainID *big.Int
+		Address common.Address
+		Nonce   uint64
+		YParity uint8
+		R, S    *big.Int
+	}
+	if err := rlp.DecodeBytes(items[9], &authList); err != nil {
+		t.Fatalf("authorization_list: %v", err)
+	}
+	if len(authList) != 1 || authList[0].ChainID.Cmp(auth.ChainID) != 0 || authList[0].Address != common.HexToAddress(auth.Address) ||
+		authList[0].Nonce != auth.Nonce || authList[0].YParity != auth.YParity || authList[0].R.Cmp(auth.R) != 0 || authList[0].S.Cmp(auth.S) != 0 {
+		t.Errorf("authorization_list = %+v, want %+v", authList, auth)
+	}
+
+	// The signature covers keccak256(0x04 || rlp(fields 0-9)).
+	unsigned := make([]rlp.RawValue, 10)
+	for i := range unsigned {
+		unsigned[i] = items[i]
+	}
+	payload, err := rlp.EncodeToBytes(unsigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var yParity uint8
+	var r, s *big.Int
+	if err := rlp.DecodeBytes(items[10], &yParity); err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(items[11], &r); err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(items[12], &s); err != nil {
+		t.Fatal(err)
+	}
+	signature := append(common.LeftPadBytes(r.Bytes(), 32), common.LeftPadBytes(s.Bytes(), 32)...)
+	pub, err := crypto.SigToPub(crypto.Keccak256(append([]byte{SetCodeTxType}, payload...)), append(signature, yParity))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("signing hash recovers %s", got.Hex())
+	}
+
+	unsignedAuth := *tx
+	unsignedAuth.AuthorizationList = []Authorization{{ChainID: big.NewInt(1), Address: auth.Address}}
+	if _, err := SignSetCodeTransaction(&unsignedAuth, key); err == nil {
+		t.Error("unsigned authorization accepted")
+	}
+	noAuth := *tx
+	noAuth.AuthorizationList = nil
+	if _, err := SignSetCodeTransaction(&noAuth, key); err == nil {
+		t.Error("empty authorization list accepted")
+	}
+}
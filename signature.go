@@ -0,0 +1,189 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignDigest signs a precomputed 32-byte digest without applying any prefix
+// and returns the 65-byte [R || S || V] signature with V set to 27 or 28.
+func SignDigest(digest []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := SignDigestRaw(digest, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// SignDigestRaw is like SignDigest but leaves V as the raw recovery id (0 or 1).
+func SignDigestRaw(digest []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+	if privateKey == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+
+	signature, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign digest: %w", err)
+	}
+	return signature, nil
+}
+
+// SplitSignature splits a 65-byte [R || S || V] signature into its parts.
+// V is accepted as either a recovery id (0/1) or in the 27/28 convention and
+// is always returned as 27 or 28.
+func SplitSignature(sig []byte) (r, s [32]byte, v uint8, err error) {
+	if len(sig) != 65 {
+		return r, s, 0, fmt.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
+
+	v = sig[64]
+	switch v {
+	case 0, 1:
+		v += 27
+	case 27, 28:
+	default:
+		return r, s, 0, fmt.Errorf("invalid signature v value: %d", v)
+	}
+
+	copy(r[:], sig[:32])
+	copy(s[:], sig[32:64])
+	return r, s, v, nil
+}
+
+// CombineSignature joins R, S and V into a 65-byte [R || S || V] signature.
+// V is written unchanged, so pass 27/28 or 0/1 depending on what the verifier
+// expects (Solidity's ecrecover wants 27/28).
+func CombineSignature(r, s [32]byte, v uint8) []byte {
+	sig := make([]byte, 65)
+	copy(sig[:32], r[:])
+	copy(sig[32:64], s[:])
+	sig[64] = v
+	return sig
+}
+
+// RecoverDigestSigner returns the address that produced the 65-byte signature
+// over a 32-byte digest. V may be 0/1 or 27/28.
+func RecoverDigestSigner(digest []byte, sig []byte) (string, error) {
+	if len(digest) != 32 {
+		return "", fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+	r, s, v, err := SplitSignature(sig)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := crypto.SigToPub(digest, CombineSignature(r, s, v-27))
+	if err != nil {
+		return "", fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey).Hex(), nil
+}
+
+// MessageSignOptions controls the encoding of message signatures. LegacyV
+// writes the recovery id V as 27/28, as Solidity's ecrecover and most wallets
+// expect; otherwise V is 0/1.
+type MessageSignOptions struct {
+	LegacyV bool
+}
+
+// SignMessage signs message as an EIP-191 personal message (the
+// "\x19Ethereum Signed Message:\n" + length prefix used by personal_sign),
+// with V as 27/28. message is taken as raw bytes: a "0x..." string passed as
+// []byte is signed as its ASCII characters, not decoded; use SignHexMessage
+// for that.
+func SignMessage(message []byte, privateKey *ecdsa.PrivateKey) (*SignedMessage, error) {
+	return SignMessageWithOptions(message, privateKey, MessageSignOptions{LegacyV: true})
+}
+
+// SignMessageWithOptions is SignMessage with explicit control over the V
+// convention of the returned signature.
+func SignMessageWithOptions(message []byte, privateKey *ecdsa.PrivateKey, opts MessageSignOptions) (*SignedMessage, error) {
+	digest := accounts.TextHash(message)
+
+	signature, err := SignDigestRaw(digest, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if opts.LegacyV {
+		signature[64] += 27
+	}
+
+	return &SignedMessage{
+		Hash:      fmt.Sprintf("0x%x", digest),
+		Signature: fmt.Sprintf("0x%x", signature),
+	}, nil
+}
+
+// SignHexMessage decodes a hex message, with or without the 0x prefix, and
+// signs the decoded bytes with SignMessage.
+func SignHexMessage(hexMessage string, privateKey *ecdsa.PrivateKey) (*SignedMessage, error) {
+	message, err := hex.DecodeString(StripHexPrefix(hexMessage))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex message: %w", err)
+	}
+	return SignMessage(message, privateKey)
+}
+
+// eip1271MagicValue is returned by isValidSignature for a valid signature.
+var eip1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+// VerifySignatureEIP1271 reports whether signature is a valid signature by
+// signer over hash. For contract accounts (e.g. Safe) it calls the EIP-1271
+// isValidSignature(bytes32,bytes) method, where a revert counts as invalid
+// and any other node error is returned; for externally owned accounts it
+// recovers the ECDSA signer.
+func VerifySignatureEIP1271(ctx context.Context, client *Client, signer string, hash []byte, signature []byte) (bool, error) {
+	if !IsAddress(signer) {
+		return false, fmt.Errorf("invalid signer address: %s", signer)
+	}
+	if len(hash) != 32 {
+		return false, fmt.Errorf("hash must be 32 bytes, got %d", len(hash))
+	}
+
+	code, err := client.Eth().GetCode(ctx, signer, BlockLatest)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code: %w", err)
+	}
+
+	if len(code) == 0 {
+		recovered, err := RecoverDigestSigner(hash, signature)
+		if err != nil {
+			return false, nil
+		}
+		return strings.EqualFold(recovered, signer), nil
+	}
+
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	args, err := abi.Arguments{{Type: bytes32Type}, {Type: bytesType}}.Pack(common.BytesToHash(hash), signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode isValidSignature call: %w", err)
+	}
+	data := append(FunctionSignature("isValidSignature(bytes32,bytes)").Selector(), args...)
+
+	result, err := client.Eth().Call(ctx, NewCallObject(signer).SetData(data), BlockLatest)
+	if err != nil {
+		// A revert means the contract rejected the signature; any other
+		// failure leaves the answer unknown.
+		if isRevert(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	returned := common.FromHex(result)
+	return len(returned) >= 4 && bytes.Equal(returned[:4], eip1271MagicValue), nil
+}
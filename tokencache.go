@@ -0,0 +1,94 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenMetadata holds the immutable ERC-20 metadata of a token contract.
+type TokenMetadata struct {
+	Symbol   string
+	Decimals uint8
+}
+
+type tokenCacheKey struct {
+	chainID  ChainID
+	contract string
+}
+
+type tokenCacheEntry struct {
+	metadata  TokenMetadata
+	fetchedAt time.Time
+}
+
+// TokenCache memoizes ERC-20 decimals() and symbol() per chain and contract
+// so repeated balance formatting does not re-read them from the chain.
+// It is safe for concurrent use.
+type TokenCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[tokenCacheKey]tokenCacheEntry
+}
+
+// NewTokenCache creates a cache whose entries expire after ttl. A zero ttl
+// keeps entries forever.
+func NewTokenCache(ttl time.Duration) *TokenCache {
+	return &TokenCache{
+		ttl:     ttl,
+		entries: make(map[tokenCacheKey]tokenCacheEntry),
+	}
+}
+
+// Metadata returns the cached metadata for the token, fetching it on a miss.
+func (tc *TokenCache) Metadata(ctx context.Context, client *Client, chainID ChainID, tokenContract string) (TokenMetadata, error) {
+	key := tokenCacheKey{chainID: chainID, contract: strings.ToLower(tokenContract)}
+
+	tc.mu.RLock()
+	entry, ok := tc.entries[key]
+	tc.mu.RUnlock()
+	if ok && (tc.ttl == 0 || time.Since(entry.fetchedAt) < tc.ttl) {
+		return entry.metadata, nil
+	}
+
+	decimals, err := GetTokenDecimals(ctx, client, tokenContract)
+	if err != nil {
+		return TokenMetadata{}, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	symbol, err := GetTokenSymbol(ctx, client, tokenContract)
+	if err != nil {
+		return TokenMetadata{}, fmt.Errorf("failed to get token symbol: %w", err)
+	}
+
+	metadata := TokenMetadata{Symbol: symbol, Decimals: decimals}
+	tc.mu.Lock()
+	tc.entries[key] = tokenCacheEntry{metadata: metadata, fetchedAt: time.Now()}
+	tc.mu.Unlock()
+
+	return metadata, nil
+}
+
+// Invalidate drops the cached metadata for a token.
+func (tc *TokenCache) Invalidate(chainID ChainID, tokenContract string) {
+	tc.mu.Lock()
+	delete(tc.entries, tokenCacheKey{chainID: chainID, contract: strings.ToLower(tokenContract)})
+	tc.mu.Unlock()
+}
+
+// FormatTokenBalance returns the token balance of address formatted with the
+// token's decimals and symbol, e.g. "12.5 USDC".
+func (tc *TokenCache) FormatTokenBalance(ctx context.Context, client *Client, chainID ChainID, tokenContract, address string) (string, error) {
+	metadata, err := tc.Metadata(ctx, client, chainID, tokenContract)
+	if err != nil {
+		return "", err
+	}
+
+	balance, err := GetTokenBalance(ctx, client, tokenContract, address)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s", FormatUnits(balance, int(metadata.Decimals)), metadata.Symbol), nil
+}
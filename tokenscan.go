@@ -0,0 +1,121 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxScanBatchSize caps the number of eth_call requests sent per batch.
+const maxScanBatchSize = 100
+
+// ScanTokenBalances reads the ERC-20 decimals of every token once and the
+// balanceOf of every address for every token, using batched eth_call
+// requests. The result is indexed by token and then address, exactly as
+// passed in, and holds raw balances in the token's base units. A token that
+// returns no data (e.g. an address without code) is an error rather than a
+// zero balance. If the node does not support batches it falls back to one
+// call per request.
+func ScanTokenBalances(ctx context.Context, client *Client, tokens []string, addresses []string) (map[string]map[string]*big.Int, error) {
+	balances, _, err := ScanTokenBalancesWithDecimals(ctx, client, tokens, addresses)
+	return balances, err
+}
+
+// ScanTokenBalancesWithDecimals is ScanTokenBalances that also returns the
+// decimals() value it read for each token, in the same round trips.
+func ScanTokenBalancesWithDecimals(ctx context.Context, client *Client, tokens []string, addresses []string) (map[string]map[string]*big.Int, map[string]uint8, error) {
+	for _, token := range tokens {
+		if !IsAddress(token) {
+			return nil, nil, fmt.Errorf("invalid token address: %s", token)
+		}
+	}
+	for _, address := range addresses {
+		if !IsAddress(address) {
+			return nil, nil, fmt.Errorf("invalid address: %s", address)
+		}
+	}
+
+	type pair struct {
+		token   string
+		address string
+	}
+
+	// The first len(tokens) elements read decimals, the rest balances.
+	batch := make([]BatchElem, 0, len(tokens)*(len(addresses)+1))
+	for _, token := range tokens {
+		batch = append(batch, BatchElem{
+			Method: EthCall.String(),
+			Params: []interface{}{NewCallObject(token).SetData(FuncDecimals.Selector()).ToMap(), BlockLatest.String()},
+		})
+	}
+	var pairs []pair
+	for _, token := range tokens {
+		for _, address := range addresses {
+			data := append(FuncBalanceOf.Selector(), common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)...)
+			pairs = append(pairs, pair{token: token, address: address})
+			batch = append(batch, BatchElem{
+				Method: EthCall.String(),
+				Params: []interface{}{NewCallObject(token).SetData(data).ToMap(), BlockLatest.String()},
+			})
+		}
+	}
+
+	for start := 0; start < len(batch); start += maxScanBatchSize {
+		chunk := batch[start:min(start+maxScanBatchSize, len(batch))]
+		if err := client.BatchCall(ctx, chunk); err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, err
+			}
+			for i := range chunk {
+				chunk[i].Result, chunk[i].Error = client.Call(ctx, chunk[i].Method, chunk[i].Params)
+			}
+		}
+	}
+
+	balances := make(map[string]map[string]*big.Int, len(tokens))
+	decimals := make(map[string]uint8, len(tokens))
+	for i, token := range tokens {
+		result, err := scanCallResult(batch[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get decimals of token %s: %w", token, err)
+		}
+		if decimals[token], err = parseTokenDecimals(result); err != nil {
+			return nil, nil, fmt.Errorf("token %s: %w", token, err)
+		}
+	}
+	for i, p := range pairs {
+		result, err := scanCallResult(batch[len(tokens)+i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get balance of %s for token %s: %w", p.address, p.token, err)
+		}
+		balance, err := FromHex(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse balance of %s for token %s: %w", p.address, p.token, err)
+		}
+
+		if balances[p.token] == nil {
+			balances[p.token] = make(map[string]*big.Int, len(addresses))
+		}
+		balances[p.token][p.address] = balance
+	}
+	return balances, decimals, nil
+}
+
+// scanCallResult returns the hex result of an eth_call batch element,
+// treating an empty "0x" result as an error.
+func scanCallResult(elem BatchElem) (string, error) {
+	if elem.Error != nil {
+		return "", elem.Error
+	}
+	var result string
+	if err := json.Unmarshal(elem.Result, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	if StripHexPrefix(result) == "" {
+		return "", fmt.Errorf("call returned no data (not an ERC-20 contract?)")
+	}
+	return result, nil
+}
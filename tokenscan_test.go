@@ -0,0 +1,63 @@
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestScanTokenBalances(t *testing.T) {
+	const (
+		usdc  = "0x00000000000000000000000000000000000000c1"
+		dai   = "0x00000000000000000000000000000000000000d1"
+		alice = "0x00000000000000000000000000000000000000a1"
+		bob   = "0x00000000000000000000000000000000000000b1"
+	)
+	word := func(n byte) string { return "0x" + strings.Repeat("00", 31) + hex.EncodeToString([]byte{n}) }
+	decimalsSelector := hex.EncodeToString(FuncDecimals.Selector())
+
+	client := newTestClient(t, map[string]rpcHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			var call struct{ To, Data string }
+			if err := json.Unmarshal(params[0], &call); err != nil {
+				return nil, err
+			}
+			isUSDC := strings.EqualFold(call.To, usdc)
+			switch {
+			case StripHexPrefix(call.Data) == decimalsSelector && isUSDC:
+				return word(6), nil
+			case StripHexPrefix(call.Data) == decimalsSelector:
+				return word(18), nil
+			case isUSDC:
+				return word(100), nil
+			default:
+				return word(200), nil
+			}
+		},
+	})
+
+	balances, decimals, err := ScanTokenBalancesWithDecimals(context.Background(), client, []string{usdc, dai}, []string{alice, bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decimals[usdc] != 6 || decimals[dai] != 18 {
+		t.Errorf("decimals = %v", decimals)
+	}
+	if len(balances) != 2 || len(balances[usdc]) != 2 || balances[dai][bob].Int64() != 200 {
+		t.Errorf("balances = %v", balances)
+	}
+
+	plain, err := ScanTokenBalances(context.Background(), client, []string{usdc}, []string{alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain[usdc][alice].Int64() != 100 {
+		t.Errorf("ScanTokenBalances = %v", plain)
+	}
+
+	if _, err := ScanTokenBalances(context.Background(), client, []string{"not a token"}, []string{alice}); err == nil {
+		t.Error("invalid token address accepted")
+	}
+}
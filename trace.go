@@ -0,0 +1,132 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// SimulateBalanceChanges executes tx against the latest state without sending
+// it and returns the net balance change of every address it touches, keyed by
+// lowercase address. It uses trace_call with a stateDiff trace and falls back
+// to geth's debug_traceCall prestate tracer on nodes without the trace_ API.
+func SimulateBalanceChanges(ctx context.Context, client *Client, tx *CallObject) (map[string]*big.Int, error) {
+	result, err := client.Call(ctx, TraceCall.String(), []interface{}{tx.ToMap(), []string{"stateDiff"}, BlockLatest.String()})
+	if errors.Is(err, ErrMethodNotSupported) {
+		return simulateBalanceChangesPrestate(ctx, client, tx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var trace struct {
+		StateDiff map[string]struct {
+			Balance json.RawMessage `json:"balance"`
+		} `json:"stateDiff"`
+	}
+	if err := json.Unmarshal(result, &trace); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state diff: %w", err)
+	}
+
+	changes := make(map[string]*big.Int)
+	for address, diff := range trace.StateDiff {
+		delta, err := parseBalanceDiff(diff.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("invalid balance diff for %s: %w", address, err)
+		}
+		if delta != nil && delta.Sign() != 0 {
+			changes[strings.ToLower(address)] = delta
+		}
+	}
+	return changes, nil
+}
+
+// parseBalanceDiff decodes a Parity-style diff: "=" for unchanged, {"+": v}
+// for a created account, {"-": v} for a deleted one and {"*": {from, to}}
+// for a modification.
+func parseBalanceDiff(raw json.RawMessage) (*big.Int, error) {
+	var unchanged string
+	if len(raw) == 0 || json.Unmarshal(raw, &unchanged) == nil {
+		return nil, nil
+	}
+
+	var diff struct {
+		Added    string `json:"+"`
+		Removed  string `json:"-"`
+		Modified *struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case diff.Modified != nil:
+		from, err := FromHex(diff.Modified.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := FromHex(diff.Modified.To)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).Sub(to, from), nil
+	case diff.Added != "":
+		return FromHex(diff.Added)
+	case diff.Removed != "":
+		removed, err := FromHex(diff.Removed)
+		if err != nil {
+			return nil, err
+		}
+		return removed.Neg(removed), nil
+	}
+	return nil, nil
+}
+
+func simulateBalanceChangesPrestate(ctx context.Context, client *Client, tx *CallObject) (map[string]*big.Int, error) {
+	tracerConfig := map[string]interface{}{
+		"tracer":       "prestateTracer",
+		"tracerConfig": map[string]interface{}{"diffMode": true},
+	}
+	result, err := client.Call(ctx, DebugTraceCall.String(), []interface{}{tx.ToMap(), BlockLatest.String(), tracerConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	type accountState struct {
+		Balance string `json:"balance"`
+	}
+	var trace struct {
+		Pre  map[string]accountState `json:"pre"`
+		Post map[string]accountState `json:"post"`
+	}
+	if err := json.Unmarshal(result, &trace); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prestate diff: %w", err)
+	}
+
+	changes := make(map[string]*big.Int)
+	for address, post := range trace.Post {
+		if post.Balance == "" {
+			continue
+		}
+		after, err := FromHex(post.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("invalid post balance for %s: %w", address, err)
+		}
+		before := big.NewInt(0)
+		if pre, ok := trace.Pre[address]; ok && pre.Balance != "" {
+			if before, err = FromHex(pre.Balance); err != nil {
+				return nil, fmt.Errorf("invalid pre balance for %s: %w", address, err)
+			}
+		}
+		if delta := new(big.Int).Sub(after, before); delta.Sign() != 0 {
+			changes[strings.ToLower(address)] = delta
+		}
+	}
+	return changes, nil
+}
@@ -4,14 +4,14 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 
-	blockchainhelper "github.com/donghquinn/go-blockchain-helper/pkg/web3"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/rlp"
 )
 
 type TransactionParams struct {
@@ -23,18 +23,22 @@ type TransactionParams struct {
 	Data     []byte   `json:"data"`
 	Nonce    uint64   `json:"nonce"`
 	ChainID  *big.Int `json:"chainId"`
+
+	checksumTo bool
+	err        error
 }
 
 type EIP1559TransactionParams struct {
-	From                 string   `json:"from"`
-	To                   string   `json:"to"`
-	Value                *big.Int `json:"value"`
-	Gas                  uint64   `json:"gas"`
-	MaxFeePerGas         *big.Int `json:"maxFeePerGas"`
-	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas"`
-	Data                 []byte   `json:"data"`
-	Nonce                uint64   `json:"nonce"`
-	ChainID              *big.Int `json:"chainId"`
+	From                 string        `json:"from"`
+	To                   string        `json:"to"`
+	Value                *big.Int      `json:"value"`
+	Gas                  uint64        `json:"gas"`
+	MaxFeePerGas         *big.Int      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int      `json:"maxPriorityFeePerGas"`
+	Data                 []byte        `json:"data"`
+	Nonce                uint64        `json:"nonce"`
+	ChainID              *big.Int      `json:"chainId"`
+	AccessList           []AccessTuple `json:"accessList"`
 }
 
 type SignedTransaction struct {
@@ -42,6 +46,93 @@ type SignedTransaction struct {
 	Raw  string `json:"raw"`
 }
 
+// SizeBytes returns the size in bytes of the encoded transaction, e.g. for
+// L1 data fee estimation.
+func (s *SignedTransaction) SizeBytes() int {
+	return s.SizeHex() / 2
+}
+
+// SizeHex returns the number of hex digits in Raw, excluding the 0x prefix.
+func (s *SignedTransaction) SizeHex() int {
+	return len(StripHexPrefix(s.Raw))
+}
+
+// transactionParamsJSON is the JSON-RPC shape of TransactionParams, with
+// quantities and data encoded as 0x-prefixed hex strings.
+type transactionParamsJSON struct {
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Gas      string `json:"gas,omitempty"`
+	GasPrice string `json:"gasPrice,omitempty"`
+	Data     string `json:"data,omitempty"`
+	Nonce    string `json:"nonce,omitempty"`
+	ChainID  string `json:"chainId,omitempty"`
+}
+
+func (tp TransactionParams) MarshalJSON() ([]byte, error) {
+	enc := transactionParamsJSON{
+		From:  tp.From,
+		To:    tp.To,
+		Gas:   ToHex(tp.Gas),
+		Data:  ToHex(tp.Data),
+		Nonce: ToHex(tp.Nonce),
+	}
+	if tp.Value != nil {
+		enc.Value = ToHex(tp.Value)
+	}
+	if tp.GasPrice != nil {
+		enc.GasPrice = ToHex(tp.GasPrice)
+	}
+	if tp.ChainID != nil {
+		enc.ChainID = ToHex(tp.ChainID)
+	}
+	return json.Marshal(enc)
+}
+
+func (tp *TransactionParams) UnmarshalJSON(data []byte) error {
+	var dec transactionParamsJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	parsed := TransactionParams{From: dec.From, To: dec.To, Data: []byte{}}
+	var err error
+	if dec.Value != "" {
+		if parsed.Value, err = FromHex(dec.Value); err != nil {
+			return fmt.Errorf("invalid value: %w", err)
+		}
+	}
+	if dec.Gas != "" {
+		if parsed.Gas, err = hexToUint64(dec.Gas); err != nil {
+			return fmt.Errorf("invalid gas: %w", err)
+		}
+	}
+	if dec.GasPrice != "" {
+		if parsed.GasPrice, err = FromHex(dec.GasPrice); err != nil {
+			return fmt.Errorf("invalid gasPrice: %w", err)
+		}
+	}
+	if dec.Data != "" {
+		if parsed.Data, err = hex.DecodeString(StripHexPrefix(dec.Data)); err != nil {
+			return fmt.Errorf("invalid data: %w", err)
+		}
+	}
+	if dec.Nonce != "" {
+		if parsed.Nonce, err = hexToUint64(dec.Nonce); err != nil {
+			return fmt.Errorf("invalid nonce: %w", err)
+		}
+	}
+	if dec.ChainID != "" {
+		if parsed.ChainID, err = FromHex(dec.ChainID); err != nil {
+			return fmt.Errorf("invalid chainId: %w", err)
+		}
+	}
+
+	*tp = parsed
+	return nil
+}
+
 func NewTransactionParams() *TransactionParams {
 	return &TransactionParams{
 		Value:   big.NewInt(0),
@@ -58,24 +149,53 @@ func NewEIP1559TransactionParams() *EIP1559TransactionParams {
 	}
 }
 
+// SetChecksumTo makes subsequent SetTo calls validate the recipient and
+// store its EIP-55 checksummed form. An invalid address leaves To unchanged
+// and is reported by Err and when signing.
+func (tp *TransactionParams) SetChecksumTo(enabled bool) *TransactionParams {
+	tp.checksumTo = enabled
+	return tp
+}
+
 func (tp *TransactionParams) SetTo(address string) *TransactionParams {
+	if tp.checksumTo && address != "" {
+		checksummed, err := ToChecksumAddress(address)
+		if err != nil {
+			if tp.err == nil {
+				tp.err = fmt.Errorf("invalid recipient: %w", err)
+			}
+			return tp
+		}
+		address = checksummed
+	}
 	tp.To = address
 	return tp
 }
 
+// Err returns the first error recorded by a builder method, if any.
+func (tp *TransactionParams) Err() error {
+	return tp.err
+}
+
 func (tp *TransactionParams) SetValue(value *big.Int) *TransactionParams {
 	tp.Value = value
 	return tp
 }
 
 func (tp *TransactionParams) SetValueInWei(wei string) *TransactionParams {
-	value, _ := new(big.Int).SetString(wei, 10)
+	value, err := ToWei(wei, Wei)
+	if err != nil && tp.err == nil {
+		tp.err = fmt.Errorf("invalid wei value: %w", err)
+	}
 	tp.Value = value
 	return tp
 }
 
 func (tp *TransactionParams) SetValueInEther(eth string) *TransactionParams {
-	value, _ := ToWei(eth, Ether)
+	value, err := ToWei(eth, Ether)
+	if err != nil && tp.err == nil {
+		tp.err = fmt.Errorf("invalid ether value: %w", err)
+	}
 	tp.Value = value
 	return tp
 }
@@ -91,7 +211,10 @@ func (tp *TransactionParams) SetGasPrice(gasPrice *big.Int) *TransactionParams {
 }
 
 func (tp *TransactionParams) SetGasPriceInGwei(gwei string) *TransactionParams {
-	gasPrice, _ := ToWei(gwei, Gwei)
+	gasPrice, err := ToWei(gwei, Gwei)
+	if err != nil && tp.err == nil {
+		tp.err = fmt.Errorf("invalid gas price: %w", err)
+	}
 	tp.GasPrice = gasPrice
 	return tp
 }
@@ -102,10 +225,10 @@ func (tp *TransactionParams) SetData(data []byte) *TransactionParams {
 }
 
 func (tp *TransactionParams) SetDataFromHex(hexData string) *TransactionParams {
-	if len(hexData) >= 2 && hexData[:2] == "0x" {
-		hexData = hexData[2:]
+	data, err := hex.DecodeString(StripHexPrefix(hexData))
+	if err != nil && tp.err == nil {
+		tp.err = fmt.Errorf("invalid data: %w", err)
 	}
-	data, _ := hex.DecodeString(hexData)
 	tp.Data = data
 	return tp
 }
@@ -121,11 +244,7 @@ func (tp *TransactionParams) SetChainID(chainID ChainID) *TransactionParams {
 }
 
 func PrivateKeyFromHex(hexKey string) (*ecdsa.PrivateKey, error) {
-	if len(hexKey) >= 2 && hexKey[:2] == "0x" {
-		hexKey = hexKey[2:]
-	}
-	
-	privateKeyBytes, err := hex.DecodeString(hexKey)
+	privateKeyBytes, err := hex.DecodeString(StripHexPrefix(hexKey))
 	if err != nil {
 		return nil, fmt.Errorf("invalid hex string: %w", err)
 	}
@@ -153,9 +272,21 @@ func PrivateKeyToAddress(privateKey *ecdsa.PrivateKey) string {
 }
 
 func SignTransaction(tx *TransactionParams, privateKey *ecdsa.PrivateKey) (*SignedTransaction, error) {
+	if tx.err != nil {
+		return nil, tx.err
+	}
 	if tx.To == "" {
 		return nil, fmt.Errorf("transaction recipient (to) is required")
 	}
+	return signLegacyTransaction(tx, keySignFunc(privateKey))
+}
+
+// signLegacyTransaction signs tx as a legacy transaction; an empty To creates
+// a contract.
+func signLegacyTransaction(tx *TransactionParams, signFn digestSignFunc) (*SignedTransaction, error) {
+	if tx.err != nil {
+		return nil, tx.err
+	}
 	if tx.GasPrice == nil {
 		return nil, fmt.Errorf("gas price is required")
 	}
@@ -178,33 +309,28 @@ func SignTransaction(tx *TransactionParams, privateKey *ecdsa.PrivateKey) (*Sign
 		Data:     tx.Data,
 	})
 
-	signer := types.NewEIP155Signer(tx.ChainID)
-	signedTx, err := types.SignTx(ethTx, signer, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	rawTxBytes, err := rlp.EncodeToBytes(signedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode transaction: %w", err)
-	}
-
-	return &SignedTransaction{
-		Hash: signedTx.Hash().Hex(),
-		Raw:  fmt.Sprintf("0x%x", rawTxBytes),
-	}, nil
+	return signWithFunc(ethTx, types.NewEIP155Signer(tx.ChainID), signFn)
 }
 
 func SignEIP1559Transaction(tx *EIP1559TransactionParams, privateKey *ecdsa.PrivateKey) (*SignedTransaction, error) {
 	if tx.To == "" {
 		return nil, fmt.Errorf("transaction recipient (to) is required")
 	}
+	return signDynamicFeeTransaction(tx, keySignFunc(privateKey))
+}
+
+// signDynamicFeeTransaction signs tx as an EIP-1559 transaction; an empty To
+// creates a contract.
+func signDynamicFeeTransaction(tx *EIP1559TransactionParams, signFn digestSignFunc) (*SignedTransaction, error) {
 	if tx.MaxFeePerGas == nil {
 		return nil, fmt.Errorf("maxFeePerGas is required")
 	}
 	if tx.MaxPriorityFeePerGas == nil {
 		return nil, fmt.Errorf("maxPriorityFeePerGas is required")
 	}
+	if err := validateFeeCaps(tx.MaxFeePerGas, tx.MaxPriorityFeePerGas); err != nil {
+		return nil, err
+	}
 	if tx.Gas == 0 {
 		return nil, fmt.Errorf("gas limit is required")
 	}
@@ -216,23 +342,159 @@ func SignEIP1559Transaction(tx *EIP1559TransactionParams, privateKey *ecdsa.Priv
 	}
 
 	ethTx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   tx.ChainID,
-		Nonce:     tx.Nonce,
-		To:        toAddr,
-		Value:     tx.Value,
-		Gas:       tx.Gas,
-		GasTipCap: tx.MaxPriorityFeePerGas,
-		GasFeeCap: tx.MaxFeePerGas,
-		Data:      tx.Data,
+		ChainID:    tx.ChainID,
+		Nonce:      tx.Nonce,
+		To:         toAddr,
+		Value:      tx.Value,
+		Gas:        tx.Gas,
+		GasTipCap:  tx.MaxPriorityFeePerGas,
+		GasFeeCap:  tx.MaxFeePerGas,
+		Data:       tx.Data,
+		AccessList: toGethAccessList(tx.AccessList),
+	})
+
+	return signWithFunc(ethTx, types.NewLondonSigner(tx.ChainID), signFn)
+}
+
+// validateFeeCaps checks the EIP-1559 fee fields for mistakes the node would
+// reject, such as swapped arguments. A zero tip is valid (and common on
+// L2s); a zero fee cap is not.
+func validateFeeCaps(maxFeePerGas, maxPriorityFeePerGas *big.Int) error {
+	if maxFeePerGas.Sign() <= 0 {
+		return fmt.Errorf("maxFeePerGas must be positive")
+	}
+	if maxPriorityFeePerGas.Sign() < 0 {
+		return fmt.Errorf("maxPriorityFeePerGas must not be negative")
+	}
+	if maxFeePerGas.Cmp(maxPriorityFeePerGas) < 0 {
+		return fmt.Errorf("maxFeePerGas must be >= maxPriorityFeePerGas")
+	}
+	return nil
+}
+
+// TxConfig is a reusable configuration for BuildTransaction. Type selects
+// the fee mode: TxTypeLegacy and TxTypeAccessList use GasPrice,
+// TxTypeDynamicFee uses MaxFeePerGas and MaxPriorityFeePerGas. AccessList is
+// ignored for legacy transactions.
+type TxConfig struct {
+	PrivateKey           *ecdsa.PrivateKey
+	ChainID              ChainID
+	Type                 TxType
+	Nonce                uint64
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	AccessList           []AccessTuple
+}
+
+// BuildTransaction builds and signs a transaction to to (empty for a
+// contract creation) from a shared TxConfig.
+func BuildTransaction(to string, value *big.Int, data []byte, cfg TxConfig) (*SignedTransaction, error) {
+	if to != "" && !IsAddress(to) {
+		return nil, fmt.Errorf("invalid recipient address: %s", to)
+	}
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	switch cfg.Type {
+	case TxTypeLegacy:
+		return signLegacyTransaction(&TransactionParams{
+			To:       to,
+			Value:    value,
+			Gas:      cfg.Gas,
+			GasPrice: cfg.GasPrice,
+			Data:     data,
+			Nonce:    cfg.Nonce,
+			ChainID:  cfg.ChainID.BigInt(),
+		}, keySignFunc(cfg.PrivateKey))
+	case TxTypeAccessList:
+		return signAccessListTransaction(to, value, data, cfg, keySignFunc(cfg.PrivateKey))
+	case TxTypeDynamicFee:
+		return signDynamicFeeTransaction(&EIP1559TransactionParams{
+			To:                   to,
+			Value:                value,
+			Gas:                  cfg.Gas,
+			MaxFeePerGas:         cfg.MaxFeePerGas,
+			MaxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+			Data:                 data,
+			Nonce:                cfg.Nonce,
+			ChainID:              cfg.ChainID.BigInt(),
+			AccessList:           cfg.AccessList,
+		}, keySignFunc(cfg.PrivateKey))
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %s", cfg.Type)
+	}
+}
+
+func signAccessListTransaction(to string, value *big.Int, data []byte, cfg TxConfig, signFn digestSignFunc) (*SignedTransaction, error) {
+	if cfg.GasPrice == nil {
+		return nil, fmt.Errorf("gas price is required")
+	}
+	if cfg.Gas == 0 {
+		return nil, fmt.Errorf("gas limit is required")
+	}
+
+	var toAddr *common.Address
+	if to != "" {
+		addr := common.HexToAddress(to)
+		toAddr = &addr
+	}
+
+	chainID := cfg.ChainID.BigInt()
+	ethTx := types.NewTx(&types.AccessListTx{
+		ChainID:    chainID,
+		Nonce:      cfg.Nonce,
+		GasPrice:   cfg.GasPrice,
+		Gas:        cfg.Gas,
+		To:         toAddr,
+		Value:      value,
+		Data:       data,
+		AccessList: toGethAccessList(cfg.AccessList),
 	})
 
-	signer := types.NewLondonSigner(tx.ChainID)
-	signedTx, err := types.SignTx(ethTx, signer, privateKey)
+	return signWithFunc(ethTx, types.NewEIP2930Signer(chainID), signFn)
+}
+
+// digestSignFunc signs a 32-byte digest and returns a 65-byte
+// [R || S || V] signature, with V as 0/1 or 27/28.
+type digestSignFunc func(digest []byte) ([]byte, error)
+
+func keySignFunc(privateKey *ecdsa.PrivateKey) digestSignFunc {
+	return func(digest []byte) ([]byte, error) {
+		return SignDigestRaw(digest, privateKey)
+	}
+}
+
+// signWithFunc signs ethTx by passing its signing hash to signFn.
+func signWithFunc(ethTx *types.Transaction, signer types.Signer, signFn digestSignFunc) (*SignedTransaction, error) {
+	digest := signer.Hash(ethTx)
+	signature, err := signFn(digest[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+
+	signature = append([]byte(nil), signature...)
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	signedTx, err := ethTx.WithSignature(signer, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return encodeSignedTransaction(signedTx)
+}
 
-	rawTxBytes, err := rlp.EncodeToBytes(signedTx)
+// encodeSignedTransaction returns the network encoding of a signed
+// transaction: plain RLP for legacy transactions, the EIP-2718 envelope for
+// typed ones.
+func encodeSignedTransaction(signedTx *types.Transaction) (*SignedTransaction, error) {
+	rawTxBytes, err := signedTx.MarshalBinary()
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode transaction: %w", err)
 	}
@@ -243,6 +505,21 @@ func SignEIP1559Transaction(tx *EIP1559TransactionParams, privateKey *ecdsa.Priv
 	}, nil
 }
 
+func toGethAccessList(tuples []AccessTuple) types.AccessList {
+	var accessList types.AccessList
+	for _, tuple := range tuples {
+		storageKeys := make([]common.Hash, len(tuple.StorageKeys))
+		for i, key := range tuple.StorageKeys {
+			storageKeys[i] = common.HexToHash(key)
+		}
+		accessList = append(accessList, types.AccessTuple{
+			Address:     common.HexToAddress(tuple.Address),
+			StorageKeys: storageKeys,
+		})
+	}
+	return accessList
+}
+
 func CreateContractDeployment(bytecode []byte, constructorData []byte, privateKey *ecdsa.PrivateKey, params *TransactionParams) (*SignedTransaction, error) {
 	params.To = ""
 	
@@ -252,7 +529,7 @@ func CreateContractDeployment(bytecode []byte, constructorData []byte, privateKe
 		params.Data = bytecode
 	}
 
-	return SignTransaction(params, privateKey)
+	return signLegacyTransaction(params, keySignFunc(privateKey))
 }
 
 func CreateContractCall(contractAddress string, methodData []byte, privateKey *ecdsa.PrivateKey, params *TransactionParams) (*SignedTransaction, error) {
@@ -262,68 +539,176 @@ func CreateContractCall(contractAddress string, methodData []byte, privateKey *e
 	return SignTransaction(params, privateKey)
 }
 
+// CreateContractDeployment1559 signs an EIP-1559 contract creation. Fees and
+// access list are taken from params.
+func CreateContractDeployment1559(bytecode []byte, constructorData []byte, privateKey *ecdsa.PrivateKey, params *EIP1559TransactionParams) (*SignedTransaction, error) {
+	params.To = ""
+	params.Data = append(append([]byte{}, bytecode...), constructorData...)
+
+	return signDynamicFeeTransaction(params, keySignFunc(privateKey))
+}
+
+// CreateContractCall1559 signs an EIP-1559 transaction calling a contract.
+// Fees and access list are taken from params.
+func CreateContractCall1559(contractAddress string, methodData []byte, privateKey *ecdsa.PrivateKey, params *EIP1559TransactionParams) (*SignedTransaction, error) {
+	params.To = contractAddress
+	params.Data = methodData
+
+	return SignEIP1559Transaction(params, privateKey)
+}
+
 func RecoverSigner(rawTxHex string) (string, error) {
-	if len(rawTxHex) >= 2 && rawTxHex[:2] == "0x" {
-		rawTxHex = rawTxHex[2:]
+	_, sender, err := decodeRawTransaction(rawTxHex)
+	if err != nil {
+		return "", err
 	}
+	return sender.Hex(), nil
+}
 
-	rawTxBytes, err := hex.DecodeString(rawTxHex)
+// decodeRawTransaction decodes a signed raw transaction and recovers its
+// sender using the signer for the transaction's own chain ID.
+func decodeRawTransaction(rawTxHex string) (*types.Transaction, common.Address, error) {
+	rawTxBytes, err := hex.DecodeString(StripHexPrefix(rawTxHex))
 	if err != nil {
-		return "", fmt.Errorf("invalid hex string: %w", err)
+		return nil, common.Address{}, fmt.Errorf("invalid hex string: %w", err)
 	}
 
 	var tx types.Transaction
-	err = rlp.DecodeBytes(rawTxBytes, &tx)
+	err = tx.UnmarshalBinary(rawTxBytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode transaction: %w", err)
+		return nil, common.Address{}, fmt.Errorf("failed to decode transaction: %w", err)
 	}
 
 	var signer types.Signer
 	if tx.ChainId().Cmp(big.NewInt(0)) == 0 {
 		signer = types.HomesteadSigner{}
 	} else {
-		signer = types.NewEIP155Signer(tx.ChainId())
+		signer = types.LatestSignerForChainID(tx.ChainId())
 	}
 
 	sender, err := signer.Sender(&tx)
 	if err != nil {
-		return "", fmt.Errorf("failed to recover sender: %w", err)
+		return nil, common.Address{}, fmt.Errorf("failed to recover sender: %w", err)
 	}
 
-	return sender.Hex(), nil
+	return &tx, sender, nil
+}
+
+// DecodedTransaction is a signed raw transaction decoded by DecodeAndVerify.
+// Fee fields that do not apply to the transaction type are nil; To is empty
+// for contract creations and ChainID is 0 for pre-EIP-155 transactions.
+type DecodedTransaction struct {
+	Hash                 string
+	Type                 TxType
+	ChainID              *big.Int
+	Nonce                uint64
+	From                 string
+	To                   string
+	Value                *big.Int
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Data                 []byte
+	AccessList           []AccessTuple
+}
+
+// DecodeAndVerify decodes a signed raw transaction, recovers its sender and
+// checks that it was signed for expectedChainID, failing with
+// ErrChainIDMismatch otherwise. Pre-EIP-155 transactions carry no chain ID
+// and are replayable on any chain, so they never match. A nil
+// expectedChainID skips the chain check.
+func DecodeAndVerify(rawTxHex string, expectedChainID *big.Int) (*DecodedTransaction, error) {
+	tx, sender, err := decodeRawTransaction(rawTxHex)
+	if err != nil {
+		return nil, err
+	}
+	if expectedChainID != nil && tx.ChainId().Cmp(expectedChainID) != 0 {
+		if tx.ChainId().Sign() == 0 {
+			return nil, fmt.Errorf("%w: transaction has no chain ID (pre-EIP-155), expected %s", ErrChainIDMismatch, expectedChainID)
+		}
+		return nil, fmt.Errorf("%w: transaction is for chain %s, expected %s", ErrChainIDMismatch, tx.ChainId(), expectedChainID)
+	}
+
+	decoded := &DecodedTransaction{
+		Hash:    tx.Hash().Hex(),
+		Type:    TxType(tx.Type()),
+		ChainID: tx.ChainId(),
+		Nonce:   tx.Nonce(),
+		From:    sender.Hex(),
+		Value:   tx.Value(),
+		Gas:     tx.Gas(),
+		Data:    tx.Data(),
+	}
+	if tx.To() != nil {
+		decoded.To = tx.To().Hex()
+	}
+	if tx.Type() == types.DynamicFeeTxType || tx.Type() == types.BlobTxType {
+		decoded.MaxFeePerGas = tx.GasFeeCap()
+		decoded.MaxPriorityFeePerGas = tx.GasTipCap()
+	} else {
+		decoded.GasPrice = tx.GasPrice()
+	}
+	for _, tuple := range tx.AccessList() {
+		entry := AccessTuple{Address: tuple.Address.Hex(), StorageKeys: []string{}}
+		for _, key := range tuple.StorageKeys {
+			entry.StorageKeys = append(entry.StorageKeys, key.Hex())
+		}
+		decoded.AccessList = append(decoded.AccessList, entry)
+	}
+	return decoded, nil
 }
 
+// EncodeABI encodes a call to methodSignature, e.g.
+// "transfer(address,uint256)", converting params to the declared types:
+// addresses and bytes may be hex strings and integers any Go integer or
+// *big.Int. A bare method name without a parameter list infers the types
+// from params instead: address or string for strings, uint256 for *big.Int,
+// uint64, bytes and bool.
 func EncodeABI(methodSignature string, params ...interface{}) ([]byte, error) {
-	// Convert params to slice for go-blockchain-helper
-	paramSlice := make([]interface{}, len(params))
-	copy(paramSlice, params)
-	
-	// Create basic ABI params - this is a simplified approach
-	// In a real implementation, you would parse the method signature to determine types
-	abiParams := make([]blockchainhelper.ABIParam, len(params))
-	for i, param := range params {
-		switch param.(type) {
-		case string:
-			if IsAddress(param.(string)) {
-				abiParams[i] = blockchainhelper.ABIParam{Type: "address"}
-			} else {
-				abiParams[i] = blockchainhelper.ABIParam{Type: "string"}
+	signature := strings.TrimSpace(methodSignature)
+	if !strings.Contains(signature, "(") {
+		inferred := make([]string, len(params))
+		for i, param := range params {
+			typ, err := inferABIType(param)
+			if err != nil {
+				return nil, err
 			}
-		case *big.Int:
-			abiParams[i] = blockchainhelper.ABIParam{Type: "uint256"}
-		case uint64:
-			abiParams[i] = blockchainhelper.ABIParam{Type: "uint64"}
-		case []byte:
-			abiParams[i] = blockchainhelper.ABIParam{Type: "bytes"}
-		case bool:
-			abiParams[i] = blockchainhelper.ABIParam{Type: "bool"}
-		default:
-			return nil, fmt.Errorf("unsupported parameter type: %T", param)
+			inferred[i] = typ
 		}
+		signature += "(" + strings.Join(inferred, ",") + ")"
+	}
+
+	name, types, _, err := parseSignature(signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid method signature %q: %w", methodSignature, err)
+	}
+	args, err := packABI(types, params)
+	if err != nil {
+		return nil, err
+	}
+	selector := FunctionSignature(name + "(" + strings.Join(types, ",") + ")").Selector()
+	return append(selector, args...), nil
+}
+
+func inferABIType(param interface{}) (string, error) {
+	switch v := param.(type) {
+	case string:
+		if IsAddress(v) {
+			return "address", nil
+		}
+		return "string", nil
+	case *big.Int:
+		return "uint256", nil
+	case uint64:
+		return "uint64", nil
+	case []byte:
+		return "bytes", nil
+	case bool:
+		return "bool", nil
+	default:
+		return "", fmt.Errorf("unsupported parameter type: %T", param)
 	}
-	
-	// Use go-blockchain-helper for ABI encoding
-	return blockchainhelper.EncodeFunctionCall(methodSignature, abiParams, paramSlice)
 }
 
 func RandomNonce() uint64 {
@@ -0,0 +1,63 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTransactionParamsAmountSetters(t *testing.T) {
+	tests := []struct {
+		setter string
+		value  string
+		want   string // empty means an error is expected
+	}{
+		{"SetValueInWei", "1000", "1000"},
+		{"SetValueInWei", "1.5", ""},
+		{"SetValueInEther", "0.5", "500000000000000000"},
+		{"SetValueInEther", "1.000000000000000001", "1000000000000000001"},
+		{"SetValueInEther", "1.0000000000000000001", ""},
+		{"SetGasPriceInGwei", "30", "30000000000"},
+		{"SetGasPriceInGwei", "0.000000001", "1"},
+		{"SetGasPriceInGwei", "0.0000000001", ""},
+	}
+	for _, setter := range []string{"SetValueInWei", "SetValueInEther", "SetGasPriceInGwei"} {
+		for _, value := range []string{"", "abc", "1e3", "Inf", "1.2.3"} {
+			tests = append(tests, struct{ setter, value, want string }{setter, value, ""})
+		}
+	}
+
+	for _, tt := range tests {
+		tp := NewTransactionParams()
+		var got *big.Int
+		switch tt.setter {
+		case "SetValueInWei":
+			got = tp.SetValueInWei(tt.value).Value
+		case "SetValueInEther":
+			got = tp.SetValueInEther(tt.value).Value
+		case "SetGasPriceInGwei":
+			got = tp.SetGasPriceInGwei(tt.value).GasPrice
+		}
+
+		err := tp.Err()
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s(%q) = %s, want error", tt.setter, tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s(%q) error: %v", tt.setter, tt.value, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s(%q) = %s, want %s", tt.setter, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionParamsKeepsFirstError(t *testing.T) {
+	tp := NewTransactionParams().SetValueInEther("1e3").SetGasPriceInGwei("abc")
+	if err := tp.Err(); err == nil || err.Error() != `invalid ether value: invalid amount "1e3": unexpected character 'e'` {
+		t.Errorf("Err() = %v, want the SetValueInEther error", err)
+	}
+}
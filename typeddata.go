@@ -0,0 +1,231 @@
+package web3
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// SignedMessage is a signature over a message digest.
+type SignedMessage struct {
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+// SignTypedData hashes typed data according to EIP-712 and signs the digest.
+// The signature's V is 27 or 28.
+func SignTypedData(typedData apitypes.TypedData, privateKey *ecdsa.PrivateKey) (*SignedMessage, error) {
+	return signTypedData(typedData, keySignFunc(privateKey))
+}
+
+func signTypedData(typedData apitypes.TypedData, signFn digestSignFunc) (*SignedMessage, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	signature, err := signFn(digest)
+	if err != nil {
+		return nil, err
+	}
+	r, sigS, v, err := SplitSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	signature = CombineSignature(r, sigS, v)
+
+	return &SignedMessage{
+		Hash:      fmt.Sprintf("0x%x", digest),
+		Signature: fmt.Sprintf("0x%x", signature),
+	}, nil
+}
+
+// EIP712Domain is an EIP-712 signing domain. Empty fields are left out of
+// the domain type, as the standard allows.
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract string
+	Salt              []byte
+}
+
+func (d EIP712Domain) typedDataDomain() ([]apitypes.Type, apitypes.TypedDataDomain, error) {
+	var fields []apitypes.Type
+	var domain apitypes.TypedDataDomain
+
+	if d.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+		domain.Name = d.Name
+	}
+	if d.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+		domain.Version = d.Version
+	}
+	if d.ChainID != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+		domain.ChainId = (*math.HexOrDecimal256)(d.ChainID)
+	}
+	if d.VerifyingContract != "" {
+		if !IsAddress(d.VerifyingContract) {
+			return nil, domain, fmt.Errorf("invalid verifying contract: %s", d.VerifyingContract)
+		}
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+		domain.VerifyingContract = d.VerifyingContract
+	}
+	if d.Salt != nil {
+		if len(d.Salt) != 32 {
+			return nil, domain, fmt.Errorf("salt must be 32 bytes, got %d", len(d.Salt))
+		}
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+		domain.Salt = fmt.Sprintf("0x%x", d.Salt)
+	}
+	return fields, domain, nil
+}
+
+// HashEIP712Domain computes the domain separator of an EIP-712 domain, the
+// value contracts expose as DOMAIN_SEPARATOR().
+func HashEIP712Domain(domain EIP712Domain) ([32]byte, error) {
+	fields, typedDomain, err := domain.typedDataDomain()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	typedData := apitypes.TypedData{
+		Types:  apitypes.Types{"EIP712Domain": fields},
+		Domain: typedDomain,
+	}
+	hash, err := typedData.HashStruct("EIP712Domain", typedDomain.Map())
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to hash domain: %w", err)
+	}
+
+	var separator [32]byte
+	copy(separator[:], hash)
+	return separator, nil
+}
+
+// PermitDetails is the per-token allowance of a Permit2 permit.
+type PermitDetails struct {
+	Token      string
+	Amount     *big.Int
+	Expiration uint64
+	Nonce      uint64
+}
+
+// Permit2Data describes a Permit2 allowance permit. With Batch unset it is
+// signed as a PermitSingle and Details must hold exactly one entry; with
+// Batch set it is signed as a PermitBatch over all of Details, which may also
+// be a single entry.
+type Permit2Data struct {
+	ChainID     ChainID
+	Batch       bool
+	Details     []PermitDetails
+	Spender     string
+	SigDeadline *big.Int
+}
+
+var permit2Types = apitypes.Types{
+	"PermitDetails": {
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint160"},
+		{Name: "expiration", Type: "uint48"},
+		{Name: "nonce", Type: "uint48"},
+	},
+	"PermitSingle": {
+		{Name: "details", Type: "PermitDetails"},
+		{Name: "spender", Type: "address"},
+		{Name: "sigDeadline", Type: "uint256"},
+	},
+	"PermitBatch": {
+		{Name: "details", Type: "PermitDetails[]"},
+		{Name: "spender", Type: "address"},
+		{Name: "sigDeadline", Type: "uint256"},
+	},
+}
+
+// SignPermit2 signs a Permit2 PermitSingle or PermitBatch for the canonical
+// Permit2 contract on details.ChainID.
+func SignPermit2(details Permit2Data, privateKey *ecdsa.PrivateKey) (*SignedMessage, error) {
+	typedData, err := permit2TypedData(details)
+	if err != nil {
+		return nil, err
+	}
+	return SignTypedData(typedData, privateKey)
+}
+
+func permit2TypedData(details Permit2Data) (apitypes.TypedData, error) {
+	if len(details.Details) == 0 {
+		return apitypes.TypedData{}, fmt.Errorf("permit details must not be empty")
+	}
+	if !details.Batch && len(details.Details) != 1 {
+		return apitypes.TypedData{}, fmt.Errorf("a PermitSingle takes exactly one entry in details, got %d; set Batch for a PermitBatch", len(details.Details))
+	}
+	if !IsAddress(details.Spender) {
+		return apitypes.TypedData{}, fmt.Errorf("invalid spender address: %s", details.Spender)
+	}
+	if details.SigDeadline == nil {
+		return apitypes.TypedData{}, fmt.Errorf("sigDeadline is required")
+	}
+
+	permits := make([]interface{}, len(details.Details))
+	for i, d := range details.Details {
+		if !IsAddress(d.Token) {
+			return apitypes.TypedData{}, fmt.Errorf("invalid token address: %s", d.Token)
+		}
+		if d.Amount == nil {
+			return apitypes.TypedData{}, fmt.Errorf("amount is required for token %s", d.Token)
+		}
+		permits[i] = map[string]interface{}{
+			"token":      d.Token,
+			"amount":     d.Amount.String(),
+			"expiration": fmt.Sprintf("%d", d.Expiration),
+			"nonce":      fmt.Sprintf("%d", d.Nonce),
+		}
+	}
+
+	primaryType := "PermitSingle"
+	var permitDetails interface{} = permits[0]
+	if details.Batch {
+		primaryType = "PermitBatch"
+		permitDetails = permits
+	}
+
+	domainFields, domain, err := EIP712Domain{
+		Name:              "Permit2",
+		ChainID:           details.ChainID.BigInt(),
+		VerifyingContract: Permit2.String(),
+	}.typedDataDomain()
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	types := apitypes.Types{
+		"EIP712Domain":  domainFields,
+		"PermitDetails": permit2Types["PermitDetails"],
+		primaryType:     permit2Types[primaryType],
+	}
+
+	return apitypes.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"details":     permitDetails,
+			"spender":     details.Spender,
+			"sigDeadline": details.SigDeadline.String(),
+		},
+	}, nil
+}
+
+// SignPermit2 signs a Permit2 permit with the wallet's key or callback.
+func (w *Wallet) SignPermit2(details Permit2Data) (*SignedMessage, error) {
+	typedData, err := permit2TypedData(details)
+	if err != nil {
+		return nil, err
+	}
+	return signTypedData(typedData, w.signDigest)
+}
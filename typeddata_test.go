@@ -0,0 +1,176 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The "Mail" example from the EIP-712 specification.
+var eip712MailDomain = EIP712Domain{
+	Name:              "Ether Mail",
+	Version:           "1",
+	ChainID:           big.NewInt(1),
+	VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+}
+
+func TestHashEIP712Domain(t *testing.T) {
+	separator, err := HashEIP712Domain(eip712MailDomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hexutil.Encode(separator[:]), "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"; got != want {
+		t.Errorf("domain separator = %s, want %s", got, want)
+	}
+
+	if _, err := HashEIP712Domain(EIP712Domain{Name: "x", VerifyingContract: "nope"}); err == nil {
+		t.Error("invalid verifying contract accepted")
+	}
+	if _, err := HashEIP712Domain(EIP712Domain{Name: "x", Salt: []byte{1}}); err == nil {
+		t.Error("short salt accepted")
+	}
+}
+
+func TestSignTypedDataMail(t *testing.T) {
+	fields, domain, err := eip712MailDomain.typedDataDomain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": fields,
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!",
+		},
+	}
+
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := SignTypedData(typedData, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"; signed.Hash != want {
+		t.Errorf("digest = %s, want %s", signed.Hash, want)
+	}
+	want := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if signed.Signature != want {
+		t.Errorf("signature = %s, want %s", signed.Signature, want)
+	}
+}
+
+// Type hashes from Permit2's PermitHash.sol and EIP712.sol. The Permit2
+// domain has no version field.
+const (
+	permit2DomainTypeHash  = "8cad95687ba82c2ce50e74f7b754645e5117c3a5bec8151c0726d5857980a866"
+	permit2DetailsTypeHash = "65626cad6cb96493bf6f5ebea28756c966f023ab9e8a83a7101849d5573b3678"
+	permit2SingleTypeHash  = "f3841cd1ff0085026a6327b620b67997ce40f282c88a8e905a7a5626e310f3d0"
+	permit2BatchTypeHash   = "af1b0d30d2cab0380e68f0689007e3254993c596f2fdd0aaa7f4d04f79440863"
+)
+
+// permit2Digest recomputes a Permit2 digest the way the contract does, by
+// hashing abi.encode'd words directly.
+func permit2Digest(t *testing.T, data Permit2Data) string {
+	t.Helper()
+	word := func(n *big.Int) []byte { return common.LeftPadBytes(n.Bytes(), 32) }
+	addr := func(a string) []byte { return common.LeftPadBytes(common.HexToAddress(a).Bytes(), 32) }
+	hash := func(parts ...[]byte) []byte { return crypto.Keccak256(parts...) }
+
+	domainSeparator := hash(common.FromHex(permit2DomainTypeHash), hash([]byte("Permit2")),
+		word(data.ChainID.BigInt()), addr(Permit2.String()))
+
+	var detailHashes []byte
+	for _, d := range data.Details {
+		detailHashes = append(detailHashes, hash(common.FromHex(permit2DetailsTypeHash), addr(d.Token), word(d.Amount),
+			word(new(big.Int).SetUint64(d.Expiration)), word(new(big.Int).SetUint64(d.Nonce)))...)
+	}
+	structHash := hash(common.FromHex(permit2SingleTypeHash), detailHashes, addr(data.Spender), word(data.SigDeadline))
+	if data.Batch {
+		structHash = hash(common.FromHex(permit2BatchTypeHash), hash(detailHashes), addr(data.Spender), word(data.SigDeadline))
+	}
+	return hexutil.Encode(hash([]byte{0x19, 0x01}, domainSeparator, structHash))
+}
+
+func TestSignPermit2(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdc := PermitDetails{
+		Token:      "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Amount:     new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1)),
+		Expiration: 1700000000,
+		Nonce:      5,
+	}
+	weth := PermitDetails{
+		Token:      "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+		Amount:     big.NewInt(1e18),
+		Expiration: 1800000000,
+	}
+	router := "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD"
+
+	tests := []Permit2Data{
+		{ChainID: ChainMainnet, Details: []PermitDetails{usdc}, Spender: router, SigDeadline: big.NewInt(1700003600)},
+		{ChainID: ChainID(8453), Details: []PermitDetails{weth}, Spender: router, SigDeadline: big.NewInt(1800003600)},
+		{ChainID: ChainMainnet, Batch: true, Details: []PermitDetails{usdc, weth}, Spender: router, SigDeadline: big.NewInt(1700003600)},
+		{ChainID: ChainMainnet, Batch: true, Details: []PermitDetails{usdc}, Spender: router, SigDeadline: big.NewInt(1700003600)},
+	}
+	for i, data := range tests {
+		typedData, err := permit2TypedData(data)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		typeHashes := map[string]string{
+			"EIP712Domain":        permit2DomainTypeHash,
+			"PermitDetails":       permit2DetailsTypeHash,
+			typedData.PrimaryType: permit2SingleTypeHash,
+		}
+		if data.Batch {
+			typeHashes[typedData.PrimaryType] = permit2BatchTypeHash
+		}
+		for typ, want := range typeHashes {
+			if got := hexutil.Encode(typedData.TypeHash(typ)); got != "0x"+want {
+				t.Errorf("case %d: type hash of %s = %s, want 0x%s", i, typ, got, want)
+			}
+		}
+
+		signed, err := SignPermit2(data, key)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if want := permit2Digest(t, data); signed.Hash != want {
+			t.Errorf("case %d: digest = %s, want %s", i, signed.Hash, want)
+		}
+		signature := common.FromHex(signed.Signature)
+		signature[64] -= 27
+		pub, err := crypto.SigToPub(common.FromHex(signed.Hash), signature)
+		if err != nil || crypto.PubkeyToAddress(*pub) != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("case %d: signature does not recover the signer (%v)", i, err)
+		}
+	}
+
+	if _, err := SignPermit2(Permit2Data{ChainID: ChainMainnet, Details: []PermitDetails{usdc, weth}, Spender: router, SigDeadline: big.NewInt(1)}, key); err == nil {
+		t.Error("PermitSingle with two details accepted")
+	}
+}
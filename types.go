@@ -1,6 +1,14 @@
 package web3
 
-import "math/big"
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
 
 // Block parameter types
 type BlockParameter string
@@ -9,6 +17,10 @@ const (
 	BlockLatest   BlockParameter = "latest"
 	BlockEarliest BlockParameter = "earliest"
 	BlockPending  BlockParameter = "pending"
+
+	// Post-merge tags
+	BlockSafe      BlockParameter = "safe"
+	BlockFinalized BlockParameter = "finalized"
 )
 
 func (bp BlockParameter) String() string {
@@ -78,6 +90,107 @@ const (
 	ChainFantomTestnet   ChainID = 4002
 )
 
+// EIP-3770 short names, as registered in the ethereum-lists/chains registry
+var chainShortNames = map[ChainID]string{
+	ChainMainnet:        "eth",
+	ChainGoerli:         "gor",
+	ChainSepolia:        "sep",
+	ChainOptimism:       "oeth",
+	ChainOptimismGoerli: "ogor",
+	ChainArbitrum:       "arb1",
+	ChainArbitrumGoerli: "arb-goerli",
+	ChainPolygon:        "matic",
+	ChainPolygonMumbai:  "maticmum",
+	ChainAvalanche:      "avax",
+	ChainAvalancheFuji:  "fuji",
+	ChainBSC:            "bnb",
+	ChainBSCTestnet:     "bnbt",
+	ChainFantom:         "ftm",
+	ChainFantomTestnet:  "tftm",
+}
+
+// ShortName returns the EIP-3770 short name of the chain, e.g. "eth".
+func (c ChainID) ShortName() (string, bool) {
+	name, ok := chainShortNames[c]
+	return name, ok
+}
+
+// Common lowercase chain names, as typically used in configuration files
+var chainNames = map[ChainID]string{
+	ChainMainnet:        "mainnet",
+	ChainGoerli:         "goerli",
+	ChainSepolia:        "sepolia",
+	ChainOptimism:       "optimism",
+	ChainOptimismGoerli: "optimism-goerli",
+	ChainArbitrum:       "arbitrum",
+	ChainArbitrumGoerli: "arbitrum-goerli",
+	ChainPolygon:        "polygon",
+	ChainPolygonMumbai:  "polygon-mumbai",
+	ChainAvalanche:      "avalanche",
+	ChainAvalancheFuji:  "avalanche-fuji",
+	ChainBSC:            "bsc",
+	ChainBSCTestnet:     "bsc-testnet",
+	ChainFantom:         "fantom",
+	ChainFantomTestnet:  "fantom-testnet",
+}
+
+// chainCurrencies holds the native gas token of chains without a Networks
+// entry. Chains not listed here use ETH.
+var chainCurrencies = map[ChainID]string{
+	ChainPolygonMumbai: "MATIC",
+	ChainAvalanche:     "AVAX",
+	ChainAvalancheFuji: "AVAX",
+	ChainBSC:           "BNB",
+	ChainBSCTestnet:    "tBNB",
+	ChainFantom:        "FTM",
+	ChainFantomTestnet: "FTM",
+}
+
+// Currency returns the symbol of the chain's native gas token, taken from
+// Networks when configured and defaulting to "ETH".
+func (c ChainID) Currency() string {
+	if config, ok := Networks[c]; ok && config.Currency != "" {
+		return config.Currency
+	}
+	if currency, ok := chainCurrencies[c]; ok {
+		return currency
+	}
+	return "ETH"
+}
+
+// Name returns the display name of the chain from Networks, e.g. "Ethereum
+// Mainnet", falling back to its common name ("arbitrum") and then to
+// "chain <id>".
+func (c ChainID) Name() string {
+	if config, ok := Networks[c]; ok {
+		return config.Name
+	}
+	if name, ok := chainNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("chain %d", c)
+}
+
+// ChainIDFromName looks up a chain by its common name ("mainnet",
+// "polygon"), EIP-3770 short name ("eth", "matic") or Networks display name
+// ("Ethereum Mainnet"). Matching is case-insensitive.
+func ChainIDFromName(name string) (ChainID, bool) {
+	name = strings.TrimSpace(name)
+	for _, names := range []map[ChainID]string{chainNames, chainShortNames} {
+		for chainID, candidate := range names {
+			if strings.EqualFold(candidate, name) {
+				return chainID, true
+			}
+		}
+	}
+	for chainID, config := range Networks {
+		if strings.EqualFold(config.Name, name) {
+			return chainID, true
+		}
+	}
+	return 0, false
+}
+
 func (c ChainID) BigInt() *big.Int {
 	return big.NewInt(int64(c))
 }
@@ -106,6 +219,76 @@ func (ts TxStatus) IsFailure() bool {
 	return ts == TxStatusFailure
 }
 
+// TxState is the lifecycle state of a transaction as seen by the node.
+type TxState string
+
+const (
+	TxStateUnknown TxState = "unknown" // not known to the node: never seen or dropped
+	TxStatePending TxState = "pending" // in the mempool, not mined yet
+	TxStateSuccess TxState = "success" // mined and succeeded
+	TxStateFailed  TxState = "failed"  // mined and reverted
+)
+
+func (ts TxState) String() string {
+	return string(ts)
+}
+
+// Transaction types (EIP-2718)
+type TxType uint8
+
+const (
+	TxTypeLegacy     TxType = 0
+	TxTypeAccessList TxType = 1 // EIP-2930
+	TxTypeDynamicFee TxType = 2 // EIP-1559
+	TxTypeBlob       TxType = 3 // EIP-4844
+)
+
+func (tt TxType) String() string {
+	switch tt {
+	case TxTypeLegacy:
+		return "legacy"
+	case TxTypeAccessList:
+		return "accessList"
+	case TxTypeDynamicFee:
+		return "dynamicFee"
+	case TxTypeBlob:
+		return "blob"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(tt))
+	}
+}
+
+// ParseTxType parses the hex "type" field of an RPC transaction object.
+// Nodes omit the field for pre-Berlin transactions, so an empty string is
+// treated as a legacy transaction.
+func ParseTxType(hexType string) (TxType, error) {
+	if hexType == "" {
+		return TxTypeLegacy, nil
+	}
+	value, err := strconv.ParseUint(StripHexPrefix(hexType), 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid transaction type %q: %w", hexType, err)
+	}
+	return TxType(value), nil
+}
+
+func (tt TxType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToHex(uint64(tt)))
+}
+
+func (tt *TxType) UnmarshalJSON(data []byte) error {
+	var hexType string
+	if err := json.Unmarshal(data, &hexType); err != nil {
+		return fmt.Errorf("failed to unmarshal transaction type: %w", err)
+	}
+	parsed, err := ParseTxType(hexType)
+	if err != nil {
+		return err
+	}
+	*tt = parsed
+	return nil
+}
+
 // Gas price levels for optimization
 type GasPriceLevel int
 
@@ -160,6 +343,7 @@ const (
 	USDTMainnet     CommonAddress = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
 	DAIMainnet      CommonAddress = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
 	UniswapV3Router CommonAddress = "0xE592427A0AEce92De3Edee1F18E0157C05861564"
+	Permit2         CommonAddress = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
 )
 
 func (ca CommonAddress) String() string {
@@ -170,25 +354,36 @@ func (ca CommonAddress) String() string {
 type RPCMethod string
 
 const (
-	EthGetBalance              RPCMethod = "eth_getBalance"
-	EthGetBlockNumber          RPCMethod = "eth_blockNumber"
-	EthGetGasPrice             RPCMethod = "eth_gasPrice"
-	EthGetTransactionCount     RPCMethod = "eth_getTransactionCount"
-	EthGetBlockByNumber        RPCMethod = "eth_getBlockByNumber"
-	EthGetBlockByHash          RPCMethod = "eth_getBlockByHash"
-	EthGetTransactionByHash    RPCMethod = "eth_getTransactionByHash"
-	EthGetTransactionReceipt   RPCMethod = "eth_getTransactionReceipt"
-	EthSendRawTransaction      RPCMethod = "eth_sendRawTransaction"
-	EthEstimateGas             RPCMethod = "eth_estimateGas"
-	EthCall                    RPCMethod = "eth_call"
-	EthGetLogs                 RPCMethod = "eth_getLogs"
-	EthGetStorageAt            RPCMethod = "eth_getStorageAt"
-	EthGetCode                 RPCMethod = "eth_getCode"
-	NetVersion                 RPCMethod = "net_version"
-	Web3ClientVersion          RPCMethod = "web3_clientVersion"
-	EthChainId                 RPCMethod = "eth_chainId"
-	EthMaxPriorityFeePerGas    RPCMethod = "eth_maxPriorityFeePerGas"
-	EthFeeHistory              RPCMethod = "eth_feeHistory"
+	EthGetBalance                 RPCMethod = "eth_getBalance"
+	EthGetBlockNumber             RPCMethod = "eth_blockNumber"
+	EthGetGasPrice                RPCMethod = "eth_gasPrice"
+	EthGetTransactionCount        RPCMethod = "eth_getTransactionCount"
+	EthGetBlockByNumber           RPCMethod = "eth_getBlockByNumber"
+	EthGetBlockByHash             RPCMethod = "eth_getBlockByHash"
+	EthGetTransactionByHash       RPCMethod = "eth_getTransactionByHash"
+	EthGetTransactionReceipt      RPCMethod = "eth_getTransactionReceipt"
+	EthSendRawTransaction         RPCMethod = "eth_sendRawTransaction"
+	EthEstimateGas                RPCMethod = "eth_estimateGas"
+	EthCall                       RPCMethod = "eth_call"
+	EthGetLogs                    RPCMethod = "eth_getLogs"
+	EthGetStorageAt               RPCMethod = "eth_getStorageAt"
+	EthGetCode                    RPCMethod = "eth_getCode"
+	EthGetUncleCountByBlockNumber RPCMethod = "eth_getUncleCountByBlockNumber"
+	NetVersion                    RPCMethod = "net_version"
+	Web3ClientVersion             RPCMethod = "web3_clientVersion"
+	EthChainId                    RPCMethod = "eth_chainId"
+	EthMaxPriorityFeePerGas       RPCMethod = "eth_maxPriorityFeePerGas"
+	EthFeeHistory                 RPCMethod = "eth_feeHistory"
+	EthCreateAccessList           RPCMethod = "eth_createAccessList"
+	EthGetBlockReceipts           RPCMethod = "eth_getBlockReceipts"
+	EthSubscribe                  RPCMethod = "eth_subscribe"
+	EthUnsubscribe                RPCMethod = "eth_unsubscribe"
+	TraceCall                     RPCMethod = "trace_call"
+	TraceBlock                    RPCMethod = "trace_block"
+	DebugTraceCall                RPCMethod = "debug_traceCall"
+	DebugTraceBlockByNumber       RPCMethod = "debug_traceBlockByNumber"
+	TxpoolContent                 RPCMethod = "txpool_content"
+	TxpoolContentFrom             RPCMethod = "txpool_contentFrom"
 )
 
 func (rm RPCMethod) String() string {
@@ -217,13 +412,19 @@ func (fs FunctionSignature) String() string {
 	return string(fs)
 }
 
+// Selector returns the 4-byte function selector for the signature.
+func (fs FunctionSignature) Selector() []byte {
+	return crypto.Keccak256([]byte(fs))[:4]
+}
+
 // Network configurations
 type NetworkConfig struct {
-	Name     string
-	ChainID  ChainID
-	Currency string
-	RPC      []string
-	Explorer string
+	Name        string
+	ChainID     ChainID
+	Currency    string
+	RPC         []string
+	Explorer    string
+	ExplorerAPI string // Etherscan-compatible API endpoint
 }
 
 var Networks = map[ChainID]NetworkConfig{
@@ -236,7 +437,8 @@ var Networks = map[ChainID]NetworkConfig{
 			"https://eth-mainnet.alchemyapi.io/v2/YOUR_API_KEY",
 			"https://rpc.ankr.com/eth",
 		},
-		Explorer: "https://etherscan.io",
+		Explorer:    "https://etherscan.io",
+		ExplorerAPI: "https://api.etherscan.io/api",
 	},
 	ChainGoerli: {
 		Name:     "Goerli Testnet",
@@ -246,7 +448,8 @@ var Networks = map[ChainID]NetworkConfig{
 			"https://goerli.infura.io/v3/YOUR_PROJECT_ID",
 			"https://eth-goerli.alchemyapi.io/v2/YOUR_API_KEY",
 		},
-		Explorer: "https://goerli.etherscan.io",
+		Explorer:    "https://goerli.etherscan.io",
+		ExplorerAPI: "https://api-goerli.etherscan.io/api",
 	},
 	ChainSepolia: {
 		Name:     "Sepolia Testnet",
@@ -256,7 +459,8 @@ var Networks = map[ChainID]NetworkConfig{
 			"https://sepolia.infura.io/v3/YOUR_PROJECT_ID",
 			"https://eth-sepolia.alchemyapi.io/v2/YOUR_API_KEY",
 		},
-		Explorer: "https://sepolia.etherscan.io",
+		Explorer:    "https://sepolia.etherscan.io",
+		ExplorerAPI: "https://api-sepolia.etherscan.io/api",
 	},
 	ChainPolygon: {
 		Name:     "Polygon",
@@ -266,6 +470,7 @@ var Networks = map[ChainID]NetworkConfig{
 			"https://polygon-mainnet.infura.io/v3/YOUR_PROJECT_ID",
 			"https://polygon-mainnet.g.alchemy.com/v2/YOUR_API_KEY",
 		},
-		Explorer: "https://polygonscan.com",
+		Explorer:    "https://polygonscan.com",
+		ExplorerAPI: "https://api.polygonscan.com/api",
 	},
 }
\ No newline at end of file
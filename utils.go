@@ -5,45 +5,113 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// ToWei converts a plain decimal amount of unit, e.g. "1.5" ether, to wei
+// exactly. Exponents, hex and a fraction finer than 1 wei are errors.
 func ToWei(value string, unit EtherUnit) (*big.Int, error) {
-	val, ok := new(big.Float).SetString(value)
+	decimals, ok := unitDecimals(unit)
+	if !ok {
+		return nil, fmt.Errorf("unknown unit: %s", unit)
+	}
+	return parseDecimalAmount(value, decimals)
+}
+
+// ParseAmountLocale converts a user-entered amount written with the given
+// decimal and thousands separators, e.g. "1.234,56" with ',' and '.', to wei
+// exactly. Thousands separators must split the integer part into groups of
+// three digits and may not appear after the decimal separator; a fraction
+// finer than the unit allows (e.g. below 1 wei) is an error.
+func ParseAmountLocale(value string, unit string, decimalSep, thousandsSep rune) (*big.Int, error) {
+	if decimalSep == thousandsSep {
+		return nil, fmt.Errorf("decimal and thousands separators must differ")
+	}
+	decimals, ok := unitDecimals(EtherUnit(unit))
 	if !ok {
-		return nil, fmt.Errorf("invalid value: %s", value)
+		return nil, fmt.Errorf("unknown unit: %s", unit)
+	}
+
+	value = strings.TrimSpace(value)
+	integer, fraction, hasFraction := strings.Cut(value, string(decimalSep))
+	if strings.ContainsRune(fraction, decimalSep) {
+		return nil, fmt.Errorf("invalid amount %q: multiple decimal separators", value)
+	}
+	if strings.ContainsRune(fraction, thousandsSep) {
+		return nil, fmt.Errorf("invalid amount %q: thousands separator after decimal separator", value)
+	}
+
+	groups := strings.Split(strings.TrimPrefix(integer, "-"), string(thousandsSep))
+	for i, group := range groups[1:] {
+		if len(group) != 3 || (i == 0 && (groups[0] == "" || len(groups[0]) > 3)) {
+			return nil, fmt.Errorf("invalid amount %q: misplaced thousands separator", value)
+		}
+	}
+
+	normalized := strings.ReplaceAll(integer, string(thousandsSep), "")
+	if hasFraction {
+		normalized += "." + fraction
 	}
+	return parseDecimalAmount(normalized, decimals)
+}
 
-	var multiplier *big.Int
+// unitDecimals returns the number of decimal places between unit and wei.
+func unitDecimals(unit EtherUnit) (int, bool) {
 	switch unit {
 	case Wei:
-		multiplier = big.NewInt(1)
+		return 0, true
 	case Kwei, Babbage, Femtoether:
-		multiplier = big.NewInt(1e3)
+		return 3, true
 	case Mwei, Lovelace, Picoether:
-		multiplier = big.NewInt(1e6)
+		return 6, true
 	case Gwei, Shannon, Nanoether, Nano:
-		multiplier = big.NewInt(1e9)
+		return 9, true
 	case Szabo, Microether, Micro:
-		multiplier = big.NewInt(1e12)
+		return 12, true
 	case Finney, Milliether, Milli:
-		multiplier = big.NewInt(1e15)
+		return 15, true
 	case Ether, EthUnit:
-		multiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+		return 18, true
 	case Kether, Grand:
-		multiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil)
+		return 21, true
 	case Mether:
-		multiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+		return 24, true
 	case Gether:
-		multiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
+		return 27, true
 	case Tether:
-		multiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+		return 30, true
 	default:
-		return nil, fmt.Errorf("unknown unit: %s", unit)
+		return 0, false
 	}
+}
 
-	result := new(big.Float).Mul(val, new(big.Float).SetInt(multiplier))
-	wei, _ := result.Int(nil)
-	return wei, nil
+// parseDecimalAmount parses a plain decimal number such as "1", "-0.5" or
+// ".25" and scales it by 10^decimals without going through floating point.
+// A fraction with more than decimals digits is an error, not truncated.
+func parseDecimalAmount(amount string, decimals int) (*big.Int, error) {
+	digits, negative := strings.CutPrefix(amount, "-")
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if integer == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount %q: no digits", amount)
+	}
+	for _, r := range integer + fraction {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("invalid amount %q: unexpected character %q", amount, r)
+		}
+	}
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("invalid amount %q: more than %d decimal places", amount, decimals)
+	}
+
+	value, ok := new(big.Int).SetString(integer+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if negative {
+		value.Neg(value)
+	}
+	return value, nil
 }
 
 func FromWei(wei *big.Int, unit EtherUnit) (string, error) {
@@ -51,33 +119,11 @@ func FromWei(wei *big.Int, unit EtherUnit) (string, error) {
 		return "0", nil
 	}
 
-	var divisor *big.Int
-	switch unit {
-	case Wei:
-		divisor = big.NewInt(1)
-	case Kwei, Babbage, Femtoether:
-		divisor = big.NewInt(1e3)
-	case Mwei, Lovelace, Picoether:
-		divisor = big.NewInt(1e6)
-	case Gwei, Shannon, Nanoether, Nano:
-		divisor = big.NewInt(1e9)
-	case Szabo, Microether, Micro:
-		divisor = big.NewInt(1e12)
-	case Finney, Milliether, Milli:
-		divisor = big.NewInt(1e15)
-	case Ether, EthUnit:
-		divisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	case Kether, Grand:
-		divisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil)
-	case Mether:
-		divisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
-	case Gether:
-		divisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
-	case Tether:
-		divisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
-	default:
+	decimals, ok := unitDecimals(unit)
+	if !ok {
 		return "", fmt.Errorf("unknown unit: %s", unit)
 	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
 
 	result := new(big.Float).Quo(new(big.Float).SetInt(wei), new(big.Float).SetInt(divisor))
 	return result.String(), nil
@@ -99,6 +145,14 @@ func IsAddress(address string) bool {
 	return true
 }
 
+// ToChecksumAddress returns the EIP-55 mixed-case checksum form of address.
+func ToChecksumAddress(address string) (string, error) {
+	if !IsAddress(address) {
+		return "", fmt.Errorf("invalid address: %s", address)
+	}
+	return common.HexToAddress(address).Hex(), nil
+}
+
 func ToHex(value interface{}) string {
 	switch v := value.(type) {
 	case int:
@@ -112,8 +166,8 @@ func ToHex(value interface{}) string {
 	case []byte:
 		return fmt.Sprintf("0x%x", v)
 	case string:
-		if strings.HasPrefix(v, "0x") {
-			return v
+		if hasHexPrefix(v) {
+			return EnsureHexPrefix(v)
 		}
 		if val, err := strconv.ParseInt(v, 10, 64); err == nil {
 			return fmt.Sprintf("0x%x", val)
@@ -124,13 +178,49 @@ func ToHex(value interface{}) string {
 	}
 }
 
+// FromHex parses a hex quantity, with or without the 0x prefix. "0x" and
+// "0x0" yield zero; an empty string or any non-hex digit is an error.
 func FromHex(hex string) (*big.Int, error) {
-	if !strings.HasPrefix(hex, "0x") {
-		return nil, fmt.Errorf("hex string must start with 0x")
+	digits := StripHexPrefix(hex)
+	if digits == "" {
+		if hasHexPrefix(hex) {
+			return big.NewInt(0), nil
+		}
+		return nil, fmt.Errorf("empty hex string")
+	}
+
+	// SetString would also accept a sign, which is not a hex digit.
+	value, ok := new(big.Int).SetString(digits, 16)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid hex string: %s", hex)
+	}
+	return value, nil
+}
+
+// StripHexPrefix removes a leading 0x or 0X from s, if present.
+func StripHexPrefix(s string) string {
+	if hasHexPrefix(s) {
+		return s[2:]
+	}
+	return s
+}
+
+// EnsureHexPrefix returns s with a lowercase 0x prefix, adding it if missing.
+func EnsureHexPrefix(s string) string {
+	return "0x" + StripHexPrefix(s)
+}
+
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// hexToUint64 parses a hex quantity, with or without the 0x prefix, failing if
+// it does not fit in a uint64.
+func hexToUint64(hexValue string) (uint64, error) {
+	value, err := strconv.ParseUint(StripHexPrefix(hexValue), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex quantity %q: %w", hexValue, err)
 	}
-	
-	value := new(big.Int)
-	value.SetString(hex[2:], 16)
 	return value, nil
 }
 
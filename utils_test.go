@@ -0,0 +1,125 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseAmountLocale(t *testing.T) {
+	tests := []struct {
+		value        string
+		unit         string
+		decimalSep   rune
+		thousandsSep rune
+		want         string // empty means an error is expected
+	}{
+		{"1.234,56", "ether", ',', '.', "1234560000000000000000"},
+		{"12.345,678901234567891", "ether", ',', '.', "12345678901234567891000"},
+		{"1,234.5", "gwei", '.', ',', "1234500000000"},
+		{"1 234 567,5", "wei", ',', ' ', ""},
+		{"1234567", "wei", '.', ',', "1234567"},
+		{"-1,000.25", "ether", '.', ',', "-1000250000000000000000"},
+		{" 0,5 ", "ether", ',', '.', "500000000000000000"},
+		{"1,2,3", "ether", '.', ',', ""},
+		{"1234,567", "ether", '.', ',', ""},
+		{",123", "ether", '.', ',', ""},
+		{"1,,000", "ether", '.', ',', ""},
+		{"1,000.5,0", "ether", '.', ',', ""},
+		{"1.5.0", "ether", '.', ',', ""},
+		{"0.0000000000000000001", "ether", '.', ',', ""},
+		{"1,5", "wei", ',', '.', ""},
+		{"1e3", "ether", '.', ',', ""},
+		{"", "ether", '.', ',', ""},
+		{"1", "parsec", '.', ',', ""},
+		{"1", "ether", '.', '.', ""},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmountLocale(tt.value, tt.unit, tt.decimalSep, tt.thousandsSep)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseAmountLocale(%q, %q) = %s, want error", tt.value, tt.unit, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmountLocale(%q, %q) error: %v", tt.value, tt.unit, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseAmountLocale(%q, %q) = %s, want %s", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestToWei(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  EtherUnit
+		want  string // empty means an error is expected
+	}{
+		{"1", Ether, "1000000000000000000"},
+		{"1.5", Ether, "1500000000000000000"},
+		{".25", Gwei, "250000000"},
+		{"-2", Gwei, "-2000000000"},
+		{"1.000000000000000001", Ether, "1000000000000000001"},
+		{"123456789012345678901234567890", Wei, "123456789012345678901234567890"},
+		{"1", Tether, "1000000000000000000000000000000"},
+		{"1.0000000000000000001", Ether, ""},
+		{"1.5", Wei, ""},
+		{"", Ether, ""},
+		{"abc", Ether, ""},
+		{"1e3", Ether, ""},
+		{"0x10", Ether, ""},
+		{"Inf", Ether, ""},
+		{"NaN", Ether, ""},
+		{"1.2.3", Ether, ""},
+		{" 1", Ether, ""},
+		{"1", EtherUnit("parsec"), ""},
+	}
+
+	for _, tt := range tests {
+		got, err := ToWei(tt.value, tt.unit)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ToWei(%q, %q) = %s, want error", tt.value, tt.unit, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ToWei(%q, %q) error: %v", tt.value, tt.unit, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ToWei(%q, %q) = %s, want %s", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestFromWei(t *testing.T) {
+	oneEther := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	tests := []struct {
+		wei  *big.Int
+		unit EtherUnit
+		want string // empty means an error is expected
+	}{
+		{nil, Ether, "0"},
+		{oneEther, Ether, "1"},
+		{oneEther, Gwei, "1000000000"},
+		{big.NewInt(1500), Kwei, "1.5"},
+		{oneEther, Finney, "1000"},
+		{oneEther, EtherUnit("parsec"), ""},
+	}
+	for _, tt := range tests {
+		got, err := FromWei(tt.wei, tt.unit)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("FromWei(%v, %q) = %s, want error", tt.wei, tt.unit, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("FromWei(%v, %q) = %s, %v; want %s", tt.wei, tt.unit, got, err, tt.want)
+		}
+	}
+}
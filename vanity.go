@@ -0,0 +1,89 @@
+package web3
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VanityDifficulty returns the expected number of keys GenerateVanityAddress
+// has to try for prefix. Every hex digit multiplies it by 16, and letters by
+// another 2 since their case must match the EIP-55 checksum. Prefixes beyond
+// 7 or 8 characters take hours to days on a typical machine.
+func VanityDifficulty(prefix string) (float64, error) {
+	prefix = StripHexPrefix(prefix)
+	if len(prefix) > 40 {
+		return 0, fmt.Errorf("vanity prefix is longer than an address")
+	}
+
+	difficulty := 1.0
+	for _, c := range prefix {
+		switch {
+		case c >= '0' && c <= '9':
+			difficulty *= 16
+		case c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			difficulty *= 32
+		default:
+			return 0, fmt.Errorf("invalid vanity prefix %q: %q is not a hex digit", prefix, c)
+		}
+	}
+	return difficulty, nil
+}
+
+// GenerateVanityAddress generates keys on workers goroutines (one per CPU if
+// zero) until one yields a checksummed address starting with prefix, e.g.
+// "0xC0FFEE" or "dead". Generation stops when ctx is done; use
+// VanityDifficulty to judge how long a prefix will take before starting.
+func GenerateVanityAddress(ctx context.Context, prefix string, workers int, client *Client) (*Wallet, error) {
+	prefix = StripHexPrefix(prefix)
+	if _, err := VanityDifficulty(prefix); err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan *ecdsa.PrivateKey, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for searchCtx.Err() == nil {
+				key, err := crypto.GenerateKey()
+				if err != nil {
+					continue
+				}
+				if strings.HasPrefix(PrivateKeyToAddress(key)[2:], prefix) {
+					select {
+					case found <- key:
+						cancel()
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case key := <-found:
+		return &Wallet{
+			privateKey: key,
+			address:    PrivateKeyToAddress(key),
+			client:     client,
+			chainID:    ChainMainnet,
+		}, nil
+	default:
+		return nil, fmt.Errorf("vanity search for %q stopped: %w", prefix, ctx.Err())
+	}
+}
@@ -3,22 +3,130 @@ package web3
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Send flow step errors. Every error returned by the wallet send methods wraps
+// exactly one of these together with the underlying cause, so callers can
+// check both the step and the cause, e.g.
+//
+//	errors.Is(err, ErrNonceFetch) && errors.Is(err, context.DeadlineExceeded)
+//
+// Only ErrBroadcast means the transaction may have reached the node.
+var (
+	ErrGasEstimation = errors.New("failed to estimate gas")
+	ErrGasPriceFetch = errors.New("failed to get gas price")
+	ErrNonceFetch    = errors.New("failed to get nonce")
+	ErrSigning       = errors.New("failed to sign transaction")
+	ErrBroadcast     = errors.New("failed to send transaction")
 )
 
+// ErrMaxFeeCapBelowBaseFee is returned by EIP-1559 sends when
+// TransferOptions.MaxFeeCap is below the current base fee, so the
+// transaction could not be included.
+var ErrMaxFeeCapBelowBaseFee = errors.New("max fee cap is below the current base fee")
+
+// MayHaveBeenBroadcast reports whether a send error leaves it unknown whether
+// the transaction reached the network. That is the case when broadcasting
+// failed without the node explicitly rejecting it (e.g. a timeout or a
+// dropped connection); the caller should then check for the transaction
+// before retrying.
+func MayHaveBeenBroadcast(err error) bool {
+	if !errors.Is(err, ErrBroadcast) || errors.Is(err, ErrChainIDMismatch) {
+		return false
+	}
+	var rpcErr *RPCError
+	return !errors.As(err, &rpcErr)
+}
+
 type Wallet struct {
-	privateKey *ecdsa.PrivateKey
-	address    string
-	client     *Client
+	privateKey    *ecdsa.PrivateKey
+	signFn        digestSignFunc // set for callback wallets instead of privateKey
+	address       string
+	client        *Client
+	chainID       ChainID
+	nonceRecovery bool
 }
 
+// TransferOptions describes a transaction sent by the wallet. When GasLimit
+// is zero the gas is estimated and multiplied by GasMultiplier (1.0 if
+// unset) to leave headroom; a GasMultiplier below 1 would under-gas the
+// transaction and makes the send fail. If the estimate fails for any reason other than
+// a revert and GasLimitFallback is non-zero, GasLimitFallback is used as the
+// gas limit instead. MaxFeeCap, if set, is a hard ceiling on the
+// MaxFeePerGas of EIP-1559 sends. An empty To with Data deploys a contract.
 type TransferOptions struct {
-	To       string
-	Value    *big.Int
-	GasLimit uint64
-	GasPrice *big.Int
-	Data     []byte
+	To               string
+	Value            *big.Int
+	GasLimit         uint64
+	GasPrice         *big.Int
+	Data             []byte
+	GasMultiplier    float64
+	GasLimitFallback uint64
+	MaxFeeCap        *big.Int
+}
+
+func (opts *TransferOptions) padGas(estimate uint64) (uint64, error) {
+	if opts.GasMultiplier == 0 {
+		return estimate, nil
+	}
+	if !(opts.GasMultiplier >= 1) || math.IsInf(opts.GasMultiplier, 1) {
+		return 0, fmt.Errorf("invalid gas multiplier %v: must be at least 1", opts.GasMultiplier)
+	}
+	return uint64(float64(estimate) * opts.GasMultiplier), nil
+}
+
+// capFees lowers maxFee to opts.MaxFeeCap and priorityFee to the resulting
+// max fee. It fails with ErrMaxFeeCapBelowBaseFee if the cap cannot cover
+// baseFee. The inputs are not modified.
+func (opts *TransferOptions) capFees(maxFee, priorityFee, baseFee *big.Int) (*big.Int, *big.Int, error) {
+	if opts.MaxFeeCap == nil {
+		return maxFee, priorityFee, nil
+	}
+	if baseFee != nil && opts.MaxFeeCap.Cmp(baseFee) < 0 {
+		return nil, nil, fmt.Errorf("%w: cap %s, base fee %s", ErrMaxFeeCapBelowBaseFee, opts.MaxFeeCap, baseFee)
+	}
+	if maxFee == nil || maxFee.Cmp(opts.MaxFeeCap) > 0 {
+		maxFee = opts.MaxFeeCap
+	}
+	if priorityFee != nil && priorityFee.Cmp(maxFee) > 0 {
+		priorityFee = maxFee
+	}
+	return new(big.Int).Set(maxFee), priorityFee, nil
+}
+
+// JSON-RPC error code geth and most providers use for a reverted execution.
+const rpcCodeExecutionReverted = 3
+
+// isRevert reports whether err is a node reporting that the call reverted, as
+// opposed to the estimate or call failing for infrastructure reasons.
+func isRevert(err error) bool {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.Code == rpcCodeExecutionReverted || strings.Contains(strings.ToLower(rpcErr.Message), "revert")
+}
+
+// estimateGas estimates the gas of opts from the wallet, falling back to
+// opts.GasLimitFallback when the estimate fails without a revert.
+func (w *Wallet) estimateGas(ctx context.Context, callObj *CallObject, opts *TransferOptions) (uint64, error) {
+	gas, err := w.client.Eth().EstimateGas(ctx, callObj)
+	if err != nil {
+		if opts.GasLimitFallback > 0 && !isRevert(err) {
+			return opts.GasLimitFallback, nil
+		}
+		return 0, fmt.Errorf("%w: %w", ErrGasEstimation, err)
+	}
+	return opts.padGas(gas)
 }
 
 type SendTransactionResult struct {
@@ -29,6 +137,7 @@ type SendTransactionResult struct {
 	GasUsed         uint64
 	BlockNumber     uint64
 	Status          bool
+	RawTransaction  string // signed transaction as broadcast, 0x-prefixed
 }
 
 func NewWallet(privateKeyHex string, client *Client) (*Wallet, error) {
@@ -43,9 +152,27 @@ func NewWallet(privateKeyHex string, client *Client) (*Wallet, error) {
 		privateKey: privateKey,
 		address:    address,
 		client:     client,
+		chainID:    ChainMainnet,
 	}, nil
 }
 
+// NewWalletExpectingAddress is like NewWallet but fails unless the key
+// belongs to expectedAddress (compared case-insensitively), catching
+// key/account mix-ups at import time.
+func NewWalletExpectingAddress(privateKeyHex, expectedAddress string, client *Client) (*Wallet, error) {
+	if !IsAddress(expectedAddress) {
+		return nil, fmt.Errorf("invalid expected address: %s", expectedAddress)
+	}
+	wallet, err := NewWallet(privateKeyHex, client)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(wallet.address, expectedAddress) {
+		return nil, fmt.Errorf("private key belongs to %s, not %s", wallet.address, expectedAddress)
+	}
+	return wallet, nil
+}
+
 func CreateWallet(client *Client) (*Wallet, error) {
 	privateKey, err := GeneratePrivateKey()
 	if err != nil {
@@ -58,14 +185,88 @@ func CreateWallet(client *Client) (*Wallet, error) {
 		privateKey: privateKey,
 		address:    address,
 		client:     client,
+		chainID:    ChainMainnet,
+	}, nil
+}
+
+// NewDeterministicWallet derives a wallet from keccak256(seed || index), so the
+// same seed and index always give the same address. It is meant for tests and
+// local development only: anyone who knows the seed knows the key, so never
+// hold real funds in these wallets.
+func NewDeterministicWallet(seed string, index int, client *Client) (*Wallet, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("index must not be negative: %d", index)
+	}
+
+	var suffix [8]byte
+	binary.BigEndian.PutUint64(suffix[:], uint64(index))
+	keyBytes := crypto.Keccak256([]byte(seed), suffix[:])
+
+	// A hash outside the valid scalar range is astronomically unlikely;
+	// rehashing keeps the derivation total.
+	privateKey, err := crypto.ToECDSA(keyBytes)
+	for err != nil {
+		keyBytes = crypto.Keccak256(keyBytes)
+		privateKey, err = crypto.ToECDSA(keyBytes)
+	}
+
+	return &Wallet{
+		privateKey: privateKey,
+		address:    PrivateKeyToAddress(privateKey),
+		client:     client,
+		chainID:    ChainMainnet,
 	}, nil
 }
 
+// NewCallbackWallet creates a wallet for address whose key is held elsewhere,
+// e.g. in a remote signing service. The wallet computes every digest itself
+// and passes it to signFn, which must return a 65-byte [R || S || V]
+// signature (V as 0/1 or 27/28) made by address's key. Signatures from any
+// other key are rejected.
+func NewCallbackWallet(address string, signFn func(digest []byte) ([]byte, error), client *Client) *Wallet {
+	if checksummed, err := ToChecksumAddress(address); err == nil {
+		address = checksummed
+	}
+	return &Wallet{
+		signFn:  signFn,
+		address: address,
+		client:  client,
+		chainID: ChainMainnet,
+	}
+}
+
+// signDigest signs a 32-byte digest with the wallet's key or callback,
+// returning the signature with V as the raw recovery id (0 or 1).
+func (w *Wallet) signDigest(digest []byte) ([]byte, error) {
+	if w.signFn == nil {
+		return SignDigestRaw(digest, w.privateKey)
+	}
+
+	signature, err := w.signFn(digest)
+	if err != nil {
+		return nil, err
+	}
+	recovered, err := RecoverDigestSigner(digest, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(recovered, w.address) {
+		return nil, fmt.Errorf("signature was made by %s, not wallet address %s", recovered, w.address)
+	}
+	r, sigS, v, _ := SplitSignature(signature)
+	return CombineSignature(r, sigS, v-27), nil
+}
+
 func (w *Wallet) GetAddress() string {
 	return w.address
 }
 
+// GetPrivateKey returns the hex-encoded private key, or an empty string for
+// callback wallets.
 func (w *Wallet) GetPrivateKey() string {
+	if w.privateKey == nil {
+		return ""
+	}
 	return PrivateKeyToHex(w.privateKey)
 }
 
@@ -73,35 +274,159 @@ func (w *Wallet) GetBalance(ctx context.Context) (*big.Int, error) {
 	return w.client.Eth().GetBalance(ctx, w.address, "latest")
 }
 
+// AvailableBalance returns the wallet's balance minus the most its mempool
+// transactions can spend: their value plus gas times the fee cap
+// (maxFeePerGas, or gasPrice for legacy transactions). It never goes below
+// zero.
+//
+// Pending and queued transactions are read from the txpool namespace (see
+// GetTxPoolTransactions). Nodes without it only expose the pending block, so
+// there transactions that are queued or did not fit the block are missed and
+// the result can be too high.
+func (w *Wallet) AvailableBalance(ctx context.Context) (*big.Int, error) {
+	balance, err := w.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := w.client.Eth().GetTxPoolTransactions(ctx, w.address)
+	if errors.Is(err, ErrMethodNotSupported) {
+		pending, err = w.client.Eth().GetAccountPendingTransactions(ctx, w.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transactions: %w", err)
+	}
+
+	available := new(big.Int).Set(balance)
+	for _, tx := range pending {
+		if !strings.EqualFold(tx.From, w.address) {
+			continue
+		}
+		outflow, err := pendingOutflow(tx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending transaction %s: %w", tx.Hash, err)
+		}
+		available.Sub(available, outflow)
+	}
+	if available.Sign() < 0 {
+		available.SetInt64(0)
+	}
+	return available, nil
+}
+
+// pendingOutflow is the most a pending transaction can take from its sender.
+func pendingOutflow(tx *Transaction) (*big.Int, error) {
+	value, err := FromHex(tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	gas, err := FromHex(tx.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas: %w", err)
+	}
+	feeCap := tx.MaxFeePerGas
+	if feeCap == "" {
+		feeCap = tx.GasPrice
+	}
+	price, err := FromHex(feeCap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee cap: %w", err)
+	}
+	return value.Add(value, gas.Mul(gas, price)), nil
+}
+
 func (w *Wallet) GetNonce(ctx context.Context) (uint64, error) {
 	return w.client.Eth().GetTransactionCount(ctx, w.address, BlockPending)
 }
 
+// SetChainID sets the chain the wallet signs transactions for. It defaults to
+// ChainMainnet.
+func (w *Wallet) SetChainID(chainID ChainID) *Wallet {
+	w.chainID = chainID
+	return w
+}
+
+// GetChainID returns the chain the wallet signs transactions for.
+func (w *Wallet) GetChainID() ChainID {
+	return w.chainID
+}
+
+// SetNonceRecovery enables retrying a send once with a freshly fetched nonce
+// when the node rejects it with "nonce too low", e.g. after the nonce drifted
+// from the chain.
+func (w *Wallet) SetNonceRecovery(enabled bool) *Wallet {
+	w.nonceRecovery = enabled
+	return w
+}
+
+// signAndSend signs the transaction for nonce and broadcasts it, returning
+// the transaction that was sent and its hash. With nonce recovery enabled, a
+// "nonce too low" rejection refetches the nonce and retries once.
+func (w *Wallet) signAndSend(ctx context.Context, nonce uint64, sign func(nonce uint64) (*SignedTransaction, error)) (*SignedTransaction, string, error) {
+	signedTx, err := sign(nonce)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrSigning, err)
+	}
+
+	txHash, err := w.client.Eth().SendRawTransaction(ctx, signedTx.Raw)
+	if err == nil {
+		return signedTx, txHash, nil
+	}
+	if !w.nonceRecovery || !isNonceTooLow(err) {
+		return nil, "", fmt.Errorf("%w: %w", ErrBroadcast, err)
+	}
+
+	freshNonce, nonceErr := w.GetNonce(ctx)
+	if nonceErr != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrNonceFetch, nonceErr)
+	}
+	if freshNonce <= nonce {
+		return nil, "", fmt.Errorf("%w: %w", ErrBroadcast, err)
+	}
+
+	signedTx, err = sign(freshNonce)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrSigning, err)
+	}
+	txHash, err = w.client.Eth().SendRawTransaction(ctx, signedTx.Raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrBroadcast, err)
+	}
+	return signedTx, txHash, nil
+}
+
+func isNonceTooLow(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Message), "nonce too low")
+}
+
+// SendTransaction estimates gas (if unset), fetches the gas price (if unset)
+// and nonce, signs and broadcasts a legacy transaction. Each step shares ctx;
+// a failure is reported with the step's sentinel error (ErrGasEstimation,
+// ErrGasPriceFetch, ErrNonceFetch, ErrSigning or ErrBroadcast) so callers can
+// tell whether anything was sent. See MayHaveBeenBroadcast.
 func (w *Wallet) SendTransaction(ctx context.Context, opts *TransferOptions) (*SendTransactionResult, error) {
 	if opts.GasLimit == 0 {
-		gasEstimate, err := w.client.Eth().EstimateGas(ctx, map[string]interface{}{
-			"from":  w.address,
-			"to":    opts.To,
-			"value": fmt.Sprintf("0x%x", opts.Value),
-			"data":  fmt.Sprintf("0x%x", opts.Data),
-		})
+		gasLimit, err := w.estimateGas(ctx, NewCallObject(opts.To).
+			SetFrom(w.address).
+			SetValue(opts.Value).
+			SetData(opts.Data), opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, err
 		}
-		opts.GasLimit = gasEstimate + (gasEstimate * 10 / 100)
+		opts.GasLimit = gasLimit
 	}
 
 	if opts.GasPrice == nil {
 		gasPrice, err := w.client.Eth().GetGasPrice(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get gas price: %w", err)
+			return nil, fmt.Errorf("%w: %w", ErrGasPriceFetch, err)
 		}
 		opts.GasPrice = gasPrice
 	}
 
 	nonce, err := w.GetNonce(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrNonceFetch, err)
 	}
 
 	txParams := NewTransactionParams().
@@ -110,24 +435,24 @@ func (w *Wallet) SendTransaction(ctx context.Context, opts *TransferOptions) (*S
 		SetGas(opts.GasLimit).
 		SetGasPrice(opts.GasPrice).
 		SetData(opts.Data).
-		SetNonce(nonce).
-		SetChainID(ChainMainnet)
-
-	signedTx, err := SignTransaction(txParams, w.privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
+		SetChainID(w.chainID)
 
-	txHash, err := w.client.Eth().SendRawTransaction(ctx, signedTx.Raw)
+	signedTx, txHash, err := w.signAndSend(ctx, nonce, func(nonce uint64) (*SignedTransaction, error) {
+		if opts.To == "" && len(opts.Data) == 0 {
+			return nil, fmt.Errorf("transaction recipient (to) is required")
+		}
+		return signLegacyTransaction(txParams.SetNonce(nonce), w.signDigest)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		return nil, err
 	}
 
 	return &SendTransactionResult{
 		TransactionHash: txHash,
-		From:            w.address,
-		To:              opts.To,
+		From:            w.client.formatAddress(w.address),
+		To:              w.client.formatAddress(opts.To),
 		Value:           opts.Value,
+		RawTransaction:  signedTx.Raw,
 	}, nil
 }
 
@@ -152,21 +477,29 @@ func (w *Wallet) SendWei(ctx context.Context, to string, amountInWei *big.Int) (
 
 func (w *Wallet) SendEIP1559Transaction(ctx context.Context, opts *TransferOptions, maxFeePerGas, maxPriorityFeePerGas *big.Int) (*SendTransactionResult, error) {
 	if opts.GasLimit == 0 {
-		gasEstimate, err := w.client.Eth().EstimateGas(ctx, map[string]interface{}{
-			"from":  w.address,
-			"to":    opts.To,
-			"value": fmt.Sprintf("0x%x", opts.Value),
-			"data":  fmt.Sprintf("0x%x", opts.Data),
-		})
+		gasLimit, err := w.estimateGas(ctx, NewCallObject(opts.To).
+			SetFrom(w.address).
+			SetValue(opts.Value).
+			SetData(opts.Data), opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.GasLimit = gasLimit
+	}
+
+	if opts.MaxFeeCap != nil {
+		baseFee, err := w.client.Eth().GetBaseFee(ctx, BlockLatest)
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, fmt.Errorf("%w: %w", ErrGasPriceFetch, err)
+		}
+		if maxFeePerGas, maxPriorityFeePerGas, err = opts.capFees(maxFeePerGas, maxPriorityFeePerGas, baseFee); err != nil {
+			return nil, err
 		}
-		opts.GasLimit = gasEstimate + (gasEstimate * 10 / 100)
 	}
 
 	nonce, err := w.GetNonce(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrNonceFetch, err)
 	}
 
 	txParams := NewEIP1559TransactionParams()
@@ -176,37 +509,157 @@ func (w *Wallet) SendEIP1559Transaction(ctx context.Context, opts *TransferOptio
 	txParams.MaxFeePerGas = maxFeePerGas
 	txParams.MaxPriorityFeePerGas = maxPriorityFeePerGas
 	txParams.Data = opts.Data
-	txParams.Nonce = nonce
-	txParams.ChainID = ChainMainnet.BigInt()
+	txParams.ChainID = w.chainID.BigInt()
 
-	signedTx, err := SignEIP1559Transaction(txParams, w.privateKey)
+	signedTx, txHash, err := w.signAndSend(ctx, nonce, func(nonce uint64) (*SignedTransaction, error) {
+		if txParams.To == "" {
+			return nil, fmt.Errorf("transaction recipient (to) is required")
+		}
+		txParams.Nonce = nonce
+		return signDynamicFeeTransaction(txParams, w.signDigest)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, err
 	}
 
-	txHash, err := w.client.Eth().SendRawTransaction(ctx, signedTx.Raw)
+	return &SendTransactionResult{
+		TransactionHash: txHash,
+		From:            w.client.formatAddress(w.address),
+		To:              w.client.formatAddress(opts.To),
+		Value:           opts.Value,
+		RawTransaction:  signedTx.Raw,
+	}, nil
+}
+
+// SendOptimized sends opts in its cheapest typed form. It asks the node for
+// an access list with eth_createAccessList and attaches it only if it saves
+// gas. With opts.GasLimit unset the gas is estimated with and without the
+// list; with an explicit GasLimit nothing is estimated, and the list is
+// attached if the gas eth_createAccessList reports for it is below
+// GasLimit. With opts.GasPrice set, or on chains without a base fee, it sends
+// a type-1 transaction; otherwise a type-2 transaction priced from the latest
+// base fee and eth_gasPrice.
+func (w *Wallet) SendOptimized(ctx context.Context, opts *TransferOptions) (*SendTransactionResult, error) {
+	if opts.To == "" && len(opts.Data) == 0 {
+		return nil, fmt.Errorf("transaction recipient (to) is required")
+	}
+	eth := w.client.Eth()
+	callObj := NewCallObject(opts.To).
+		SetFrom(w.address).
+		SetValue(opts.Value).
+		SetData(opts.Data)
+
+	var accessList []AccessTuple
+	if opts.GasLimit > 0 {
+		if created, err := eth.CreateAccessList(ctx, callObj, BlockLatest); err == nil && len(created.AccessList) > 0 {
+			if listGas, err := hexToUint64(created.GasUsed); err == nil && listGas < opts.GasLimit {
+				accessList = created.AccessList
+			}
+		}
+	} else {
+		plainGas, err := eth.EstimateGas(ctx, callObj)
+		switch {
+		case err == nil:
+			gas := plainGas
+			if created, err := eth.CreateAccessList(ctx, callObj, BlockLatest); err == nil && len(created.AccessList) > 0 {
+				withList := *callObj
+				withList.AccessList = created.AccessList
+				if listGas, err := eth.EstimateGas(ctx, &withList); err == nil && listGas < plainGas {
+					accessList, gas = created.AccessList, listGas
+				}
+			}
+			if opts.GasLimit, err = opts.padGas(gas); err != nil {
+				return nil, err
+			}
+		case opts.GasLimitFallback > 0 && !isRevert(err):
+			opts.GasLimit = opts.GasLimitFallback
+		default:
+			return nil, fmt.Errorf("%w: %w", ErrGasEstimation, err)
+		}
+	}
+
+	tip, err := eth.GetChainTip(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGasPriceFetch, err)
+	}
+
+	nonce, err := w.GetNonce(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNonceFetch, err)
+	}
+
+	value := opts.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	var sign func(nonce uint64) (*SignedTransaction, error)
+	if opts.GasPrice != nil || tip.BaseFee == nil {
+		gasPrice := opts.GasPrice
+		if gasPrice == nil {
+			gasPrice = tip.GasPrice
+		}
+		sign = func(nonce uint64) (*SignedTransaction, error) {
+			return signAccessListTransaction(opts.To, value, opts.Data, TxConfig{
+				ChainID:    w.chainID,
+				Nonce:      nonce,
+				Gas:        opts.GasLimit,
+				GasPrice:   gasPrice,
+				AccessList: accessList,
+			}, w.signDigest)
+		}
+	} else {
+		priorityFee := new(big.Int).Sub(tip.GasPrice, tip.BaseFee)
+		if priorityFee.Sign() < 0 {
+			priorityFee.SetInt64(0)
+		}
+		maxFee := new(big.Int).Mul(tip.BaseFee, big.NewInt(2))
+		maxFee.Add(maxFee, priorityFee)
+		if maxFee, priorityFee, err = opts.capFees(maxFee, priorityFee, tip.BaseFee); err != nil {
+			return nil, err
+		}
+		sign = func(nonce uint64) (*SignedTransaction, error) {
+			return signDynamicFeeTransaction(&EIP1559TransactionParams{
+				To:                   opts.To,
+				Value:                value,
+				Gas:                  opts.GasLimit,
+				MaxFeePerGas:         maxFee,
+				MaxPriorityFeePerGas: priorityFee,
+				Data:                 opts.Data,
+				Nonce:                nonce,
+				ChainID:              w.chainID.BigInt(),
+				AccessList:           accessList,
+			}, w.signDigest)
+		}
+	}
+
+	signedTx, txHash, err := w.signAndSend(ctx, nonce, sign)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		return nil, err
 	}
 
 	return &SendTransactionResult{
 		TransactionHash: txHash,
-		From:            w.address,
-		To:              opts.To,
+		From:            w.client.formatAddress(w.address),
+		To:              w.client.formatAddress(opts.To),
 		Value:           opts.Value,
+		RawTransaction:  signedTx.Raw,
 	}, nil
 }
 
 func (w *Wallet) CallContract(ctx context.Context, contractAddress string, methodData []byte) (string, error) {
-	callObj := map[string]interface{}{
-		"from": w.address,
-		"to":   contractAddress,
-		"data": fmt.Sprintf("0x%x", methodData),
-	}
+	callObj := NewCallObject(contractAddress).
+		SetFrom(w.address).
+		SetData(methodData)
 
 	return w.client.Eth().Call(ctx, callObj, BlockLatest)
 }
 
+// CallContractAs executes a read-only call of data against contract at block
+// with from set to the wallet address.
+func (w *Wallet) CallContractAs(ctx context.Context, contract string, data []byte, block BlockParameter) (string, error) {
+	return CallContract(ctx, w.client, contract, data, w.address, block)
+}
+
 func (w *Wallet) SendContractTransaction(ctx context.Context, contractAddress string, methodData []byte, value *big.Int) (*SendTransactionResult, error) {
 	return w.SendTransaction(ctx, &TransferOptions{
 		To:    contractAddress,
@@ -215,26 +668,10 @@ func (w *Wallet) SendContractTransaction(ctx context.Context, contractAddress st
 	})
 }
 
+// DeployContract sends a contract creation transaction. A zero gasLimit is
+// estimated and a nil gasPrice fetched as in SendTransaction; use
+// SendTransaction with an empty To to also set a GasMultiplier.
 func (w *Wallet) DeployContract(ctx context.Context, bytecode []byte, constructorData []byte, gasLimit uint64, gasPrice *big.Int) (*SendTransactionResult, error) {
-	if gasLimit == 0 {
-		gasEstimate, err := w.client.Eth().EstimateGas(ctx, map[string]interface{}{
-			"from": w.address,
-			"data": fmt.Sprintf("0x%x", append(bytecode, constructorData...)),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
-		}
-		gasLimit = gasEstimate + (gasEstimate * 20 / 100)
-	}
-
-	if gasPrice == nil {
-		price, err := w.client.Eth().GetGasPrice(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get gas price: %w", err)
-		}
-		gasPrice = price
-	}
-
 	return w.SendTransaction(ctx, &TransferOptions{
 		To:       "",
 		Value:    big.NewInt(0),
@@ -244,16 +681,119 @@ func (w *Wallet) DeployContract(ctx context.Context, bytecode []byte, constructo
 	})
 }
 
+// ErrTransactionDropped is returned by WaitForTransactionWithConfig when the
+// transaction left the mempool without being mined, or another transaction
+// with the same nonce was mined in its place.
+var ErrTransactionDropped = errors.New("transaction dropped from mempool")
+
+// WaitConfig controls how WaitForTransactionWithConfig polls for a receipt.
+// Polling starts at InitialInterval and backs off towards MaxInterval, which
+// defaults to the wallet chain's BlockTime. A zero Timeout waits until the
+// context is done. When DropCheckAfter is set, every
+// poll after that grace period also checks whether the transaction was
+// dropped or replaced.
+type WaitConfig struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Timeout         time.Duration
+	DropCheckAfter  time.Duration
+}
+
+const (
+	defaultWaitInitialInterval = 1 * time.Second
+	waitBackoffFactor          = 1.5
+)
+
 func (w *Wallet) WaitForTransaction(ctx context.Context, txHash string) (*TransactionReceipt, error) {
+	return w.WaitForTransactionWithConfig(ctx, txHash, WaitConfig{})
+}
+
+// WaitForTransactionWithConfig polls for the receipt of txHash with an
+// adaptive interval until it is mined, the timeout elapses or ctx is done.
+// Transient errors such as rate limits keep it polling, and the last one is
+// included in the timeout error; permanent RPC or HTTP errors (an invalid
+// hash, an auth failure) are returned immediately.
+func (w *Wallet) WaitForTransactionWithConfig(ctx context.Context, txHash string, config WaitConfig) (*TransactionReceipt, error) {
+	blockTime := BlockTime(w.chainID)
+	if config.InitialInterval <= 0 {
+		config.InitialInterval = min(defaultWaitInitialInterval, blockTime)
+	}
+	if config.MaxInterval < config.InitialInterval {
+		config.MaxInterval = max(blockTime, config.InitialInterval)
+	}
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	interval := config.InitialInterval
+	started := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var lastErr error
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			receipt, err := w.client.Eth().GetTransactionReceipt(ctx, txHash)
-			if err == nil {
-				return receipt, nil
+			if lastErr != nil {
+				return nil, fmt.Errorf("waiting for transaction %s: %w (last error: %w)", txHash, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("waiting for transaction %s: %w", txHash, ctx.Err())
+		case <-timer.C:
+		}
+
+		receipt, err := w.client.Eth().GetTransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil && receipt != nil:
+			return receipt, nil
+		case err != nil && ctx.Err() == nil:
+			if isPermanentError(err) {
+				return nil, fmt.Errorf("waiting for transaction %s: %w", txHash, err)
+			}
+			lastErr = err
+		}
+
+		if config.DropCheckAfter > 0 && time.Since(started) >= config.DropCheckAfter {
+			receipt, err := w.checkDropped(ctx, txHash)
+			if err != nil || receipt != nil {
+				return receipt, err
 			}
 		}
+
+		timer.Reset(interval)
+		interval = min(time.Duration(float64(interval)*waitBackoffFactor), config.MaxInterval)
+	}
+}
+
+// checkDropped returns ErrTransactionDropped if the node no longer knows the
+// transaction or the sender's mined nonce has moved past it. If the
+// transaction got mined in the meantime its receipt is returned instead.
+func (w *Wallet) checkDropped(ctx context.Context, txHash string) (*TransactionReceipt, error) {
+	tx, err := w.client.Eth().GetTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, nil
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: %s is no longer known to the node", ErrTransactionDropped, txHash)
+	}
+	if tx.BlockHash != "" {
+		return nil, nil
+	}
+
+	txNonce, err := FromHex(tx.Nonce)
+	if err != nil {
+		return nil, nil
+	}
+	minedNonce, err := w.client.Eth().GetTransactionCount(ctx, tx.From, BlockLatest)
+	if err != nil || minedNonce <= txNonce.Uint64() {
+		return nil, nil
 	}
-}
\ No newline at end of file
+
+	// The nonce advanced; make sure it was not this transaction that got mined.
+	receipt, err := w.client.Eth().GetTransactionReceipt(ctx, txHash)
+	if err == nil && receipt != nil {
+		return receipt, nil
+	}
+	return nil, fmt.Errorf("%w: nonce %d of %s was used by another transaction", ErrTransactionDropped, txNonce.Uint64(), tx.From)
+}
@@ -0,0 +1,259 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const testPrivateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func newTestWallet(t *testing.T, handlers map[string]rpcHandler) *Wallet {
+	t.Helper()
+	wallet, err := NewWallet(testPrivateKey, newTestClient(t, handlers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wallet
+}
+
+func TestAvailableBalance(t *testing.T) {
+	from := strings.ToLower(mustWalletAddress(t))
+	tx := func(nonce, value string) map[string]interface{} {
+		return map[string]interface{}{
+			"hash": "0x" + strings.Repeat("0", 63) + nonce[2:], "from": from, "nonce": nonce,
+			"value": value, "gas": "0x5208", "gasPrice": "0x1",
+		}
+	}
+	pendingTx, queuedTx := tx("0x1", "0x64"), tx("0x3", "0xc8")
+	balance := rpcResult("0x100000")
+
+	tests := []struct {
+		name     string
+		handlers map[string]rpcHandler
+		want     int64
+	}{
+		{
+			name: "txpool_contentFrom",
+			handlers: map[string]rpcHandler{
+				"eth_getBalance": balance,
+				"txpool_contentFrom": rpcResult(map[string]interface{}{
+					"pending": map[string]interface{}{"1": pendingTx},
+					"queued":  map[string]interface{}{"3": queuedTx},
+				}),
+			},
+			want: 0x100000 - (100 + 21000) - (200 + 21000),
+		},
+		{
+			name: "txpool_content",
+			handlers: map[string]rpcHandler{
+				"eth_getBalance": balance,
+				"txpool_content": rpcResult(map[string]interface{}{
+					"pending": map[string]interface{}{
+						mustWalletAddress(t):                         map[string]interface{}{"1": pendingTx},
+						"0x00000000000000000000000000000000000000aa": map[string]interface{}{"0": tx("0x0", "0xffff")},
+					},
+					"queued": map[string]interface{}{mustWalletAddress(t): map[string]interface{}{"3": queuedTx}},
+				}),
+			},
+			want: 0x100000 - (100 + 21000) - (200 + 21000),
+		},
+		{
+			name: "pending block fallback",
+			handlers: map[string]rpcHandler{
+				"eth_getBalance": balance,
+				"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+					return map[string]interface{}{"number": "0x10", "transactions": []interface{}{pendingTx}}, nil
+				},
+			},
+			want: 0x100000 - (100 + 21000),
+		},
+	}
+
+	for _, tt := range tests {
+		wallet := newTestWallet(t, tt.handlers)
+		got, err := wallet.AvailableBalance(context.Background())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.Int64() != tt.want {
+			t.Errorf("%s: AvailableBalance = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func mustWalletAddress(t *testing.T) string {
+	t.Helper()
+	wallet, err := NewWallet(testPrivateKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wallet.GetAddress()
+}
+
+func TestWaitForTransactionErrors(t *testing.T) {
+	txHash := "0x" + strings.Repeat("ab", 32)
+	fast := WaitConfig{InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+	t.Run("permanent error returns immediately", func(t *testing.T) {
+		calls := 0
+		wallet := newTestWallet(t, map[string]rpcHandler{
+			"eth_getTransactionReceipt": func([]json.RawMessage) (interface{}, error) {
+				calls++
+				return nil, &RPCError{Code: -32602, Message: "invalid argument 0: hex string has length 3"}
+			},
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := wallet.WaitForTransactionWithConfig(ctx, txHash, fast)
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+			t.Fatalf("err = %v, want the invalid params RPC error", err)
+		}
+		if calls != 1 {
+			t.Errorf("polled %d times, want 1", calls)
+		}
+	})
+
+	t.Run("transient errors keep polling", func(t *testing.T) {
+		calls := 0
+		wallet := newTestWallet(t, map[string]rpcHandler{
+			"eth_getTransactionReceipt": func([]json.RawMessage) (interface{}, error) {
+				calls++
+				if calls < 3 {
+					return nil, &RPCError{Code: -32000, Message: "transaction indexing is in progress"}
+				}
+				return map[string]interface{}{"transactionHash": txHash, "blockNumber": "0x1", "status": "0x1"}, nil
+			},
+		})
+		receipt, err := wallet.WaitForTransactionWithConfig(context.Background(), txHash, fast)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receipt.TransactionHash != txHash || calls != 3 {
+			t.Errorf("receipt %+v after %d calls", receipt, calls)
+		}
+	})
+
+	t.Run("timeout wraps the last error", func(t *testing.T) {
+		wallet := newTestWallet(t, map[string]rpcHandler{
+			"eth_getTransactionReceipt": func([]json.RawMessage) (interface{}, error) {
+				return nil, &RPCError{Code: -32005, Message: "limit exceeded"}
+			},
+		})
+		config := fast
+		config.Timeout = 20 * time.Millisecond
+		_, err := wallet.WaitForTransactionWithConfig(context.Background(), txHash, config)
+		var rpcErr *RPCError
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &rpcErr) || rpcErr.Code != -32005 {
+			t.Errorf("err = %v, want a deadline error wrapping the rate limit error", err)
+		}
+	})
+}
+
+func TestPadGas(t *testing.T) {
+	tests := []struct {
+		multiplier float64
+		want       uint64
+		wantErr    bool
+	}{
+		{0, 21000, false},
+		{1, 21000, false},
+		{1.2, 25200, false},
+		{0.5, 0, true},
+		{-1, 0, true},
+		{math.NaN(), 0, true},
+		{math.Inf(1), 0, true},
+	}
+	for _, tt := range tests {
+		opts := &TransferOptions{GasMultiplier: tt.multiplier}
+		got, err := opts.padGas(21000)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("padGas with multiplier %v = %d, %v; want %d, error %v", tt.multiplier, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSendEIP1559TransactionCapWithoutBaseFee(t *testing.T) {
+	var sent bool
+	wallet := newTestWallet(t, map[string]rpcHandler{
+		"eth_getBlockByNumber":    rpcResult(map[string]interface{}{"number": "0x10", "timestamp": "0x1"}),
+		"eth_getTransactionCount": rpcResult("0x0"),
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			sent = true
+			return "0x" + strings.Repeat("cd", 32), nil
+		},
+	})
+	opts := &TransferOptions{
+		To:        "0x000000000000000000000000000000000000bbbb",
+		GasLimit:  21000,
+		MaxFeeCap: big.NewInt(5e9),
+	}
+	if _, err := wallet.SendEIP1559Transaction(context.Background(), opts, big.NewInt(10e9), big.NewInt(1e9)); err != nil {
+		t.Fatal(err)
+	}
+	if !sent {
+		t.Error("transaction was not broadcast")
+	}
+
+	baseFee, err := wallet.client.Eth().GetBaseFee(context.Background(), BlockLatest)
+	if err != nil || baseFee != nil {
+		t.Errorf("GetBaseFee = %v, %v; want nil, nil for a block without a base fee", baseFee, err)
+	}
+}
+
+func TestSendOptimizedWithExplicitGasLimit(t *testing.T) {
+	accessList := []map[string]interface{}{{
+		"address":     "0x000000000000000000000000000000000000cccc",
+		"storageKeys": []string{"0x" + strings.Repeat("0", 63) + "1"},
+	}}
+	for _, tt := range []struct {
+		gasLimit uint64
+		wantList bool
+	}{
+		{60000, true},  // list uses 50000 gas, within the limit
+		{40000, false}, // list would exceed the limit
+	} {
+		var raw string
+		wallet := newTestWallet(t, map[string]rpcHandler{
+			"eth_estimateGas": func([]json.RawMessage) (interface{}, error) {
+				t.Error("gas was estimated although GasLimit is set")
+				return nil, &RPCError{Code: -32000, Message: "gas required exceeds allowance"}
+			},
+			"eth_createAccessList":    rpcResult(map[string]interface{}{"accessList": accessList, "gasUsed": "0xc350"}),
+			"eth_blockNumber":         rpcResult("0x10"),
+			"eth_gasPrice":            rpcResult("0x3b9aca00"),
+			"eth_getBlockByNumber":    rpcResult(map[string]interface{}{"number": "0x10", "timestamp": "0x1", "baseFeePerGas": "0x7"}),
+			"eth_getTransactionCount": rpcResult("0x0"),
+			"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+				json.Unmarshal(params[0], &raw)
+				return "0x" + strings.Repeat("cd", 32), nil
+			},
+		})
+
+		opts := &TransferOptions{To: "0x000000000000000000000000000000000000bbbb", GasLimit: tt.gasLimit, Data: []byte{1}}
+		if _, err := wallet.SendOptimized(context.Background(), opts); err != nil {
+			t.Fatalf("gas limit %d: %v", tt.gasLimit, err)
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(common.FromHex(raw)); err != nil {
+			t.Fatal(err)
+		}
+		if tx.Gas() != tt.gasLimit {
+			t.Errorf("gas limit %d: sent with %d", tt.gasLimit, tx.Gas())
+		}
+		if got := len(tx.AccessList()) > 0; got != tt.wantList {
+			t.Errorf("gas limit %d: access list attached = %v, want %v", tt.gasLimit, got, tt.wantList)
+		}
+	}
+}
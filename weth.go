@@ -0,0 +1,77 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// wethAddresses lists the canonical WETH contract of chains whose native
+// currency is ether.
+var wethAddresses = map[ChainID]string{
+	ChainMainnet:        WETHMainnet.String(),
+	ChainGoerli:         "0xB4FBF271143F4FBf7B91A5ded31805e42b2208d6",
+	ChainSepolia:        "0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14",
+	ChainOptimism:       "0x4200000000000000000000000000000000000006",
+	ChainOptimismGoerli: "0x4200000000000000000000000000000000000006",
+	ChainArbitrum:       "0x82aF49447D8a07e3bd95BD0d56f35241523fBab1",
+	ChainID(8453):       "0x4200000000000000000000000000000000000006", // Base
+}
+
+// WETHAddress returns the WETH contract address of a chain.
+func WETHAddress(chainID ChainID) (string, error) {
+	address, ok := wethAddresses[chainID]
+	if !ok {
+		return "", fmt.Errorf("no WETH contract known for chain ID %d", chainID)
+	}
+	return address, nil
+}
+
+// WrapETH converts amount of ether into WETH by calling deposit() on the WETH
+// contract of the wallet's chain.
+func (w *Wallet) WrapETH(ctx context.Context, amount *big.Int) (*SendTransactionResult, error) {
+	if err := checkWETHAmount(amount); err != nil {
+		return nil, err
+	}
+	weth, err := WETHAddress(w.chainID)
+	if err != nil {
+		return nil, err
+	}
+	return w.SendTransaction(ctx, &TransferOptions{
+		To:    weth,
+		Value: amount,
+		Data:  FunctionSignature("deposit()").Selector(),
+	})
+}
+
+// UnwrapETH converts amount of WETH back into ether by calling
+// withdraw(uint256) on the WETH contract of the wallet's chain.
+func (w *Wallet) UnwrapETH(ctx context.Context, amount *big.Int) (*SendTransactionResult, error) {
+	if err := checkWETHAmount(amount); err != nil {
+		return nil, err
+	}
+	weth, err := WETHAddress(w.chainID)
+	if err != nil {
+		return nil, err
+	}
+	data := append(FunctionSignature("withdraw(uint256)").Selector(), common.LeftPadBytes(amount.Bytes(), 32)...)
+	return w.SendTransaction(ctx, &TransferOptions{
+		To:    weth,
+		Value: big.NewInt(0),
+		Data:  data,
+	})
+}
+
+// checkWETHAmount rejects amounts that cannot be wrapped or encoded as a
+// uint256.
+func checkWETHAmount(amount *big.Int) error {
+	if amount == nil {
+		return fmt.Errorf("amount is required")
+	}
+	if amount.Sign() < 0 || amount.BitLen() > 256 {
+		return fmt.Errorf("invalid amount: %s", amount)
+	}
+	return nil
+}